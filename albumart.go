@@ -0,0 +1,143 @@
+package juicewrld
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+)
+
+// releasedDiscographyDir holds one folder per released album, named after
+// the album title.
+const releasedDiscographyDir = "Compilation/1. Released Discography"
+
+// albumArtNames are tried in order before falling back to any image in the
+// album folder.
+var albumArtNames = []string{"cover.jpg", "cover.png", "folder.jpg", "folder.png", "front.jpg", "front.png"}
+
+type albumArtCache struct {
+	mu    sync.Mutex
+	paths map[int]string
+}
+
+func (a *albumArtCache) get(albumID int) (string, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	p, ok := a.paths[albumID]
+	return p, ok
+}
+
+func (a *albumArtCache) put(albumID int, p string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.paths == nil {
+		a.paths = make(map[int]string)
+	}
+	a.paths[albumID] = p
+}
+
+// GetAlbumCoverArt returns the album's artwork and its content type. The
+// album's own CoverArtURL or CoverArtPath is used when the API provides
+// one; otherwise the artwork is looked up in the album's folder under the
+// released discography. Resolved paths are cached per album. Albums without
+// artwork yield a *NotFoundError.
+func (c *Client) GetAlbumCoverArt(ctx context.Context, albumID int) ([]byte, string, error) {
+	if p, ok := c.albumArt.get(albumID); ok {
+		return c.fetchImage(ctx, c.downloadURL(p))
+	}
+	album, err := c.GetAlbum(ctx, albumID)
+	if err != nil {
+		return nil, "", err
+	}
+	if u := album.CoverArtURL; u != "" {
+		if strings.HasPrefix(u, "/") {
+			u = strings.TrimRight(c.BaseURL, "/") + u
+		}
+		return c.fetchImage(ctx, u)
+	}
+	p := album.CoverArtPath
+	if p == "" {
+		if p, err = c.findAlbumArt(ctx, album); err != nil {
+			return nil, "", err
+		}
+	}
+	c.albumArt.put(albumID, p)
+	return c.fetchImage(ctx, c.downloadURL(p))
+}
+
+func (c *Client) findAlbumArt(ctx context.Context, album Album) (string, error) {
+	dir, err := c.BrowseFiles(ctx, path.Join(releasedDiscographyDir, album.Title), nil)
+	if err != nil {
+		return "", err
+	}
+	byName := map[string]string{}
+	fallback := ""
+	for _, item := range dir.Items {
+		if KindOf(item) != FileKindImage {
+			continue
+		}
+		byName[strings.ToLower(item.Name)] = item.Path
+		if fallback == "" {
+			fallback = item.Path
+		}
+	}
+	for _, name := range albumArtNames {
+		if p, ok := byName[name]; ok {
+			return p, nil
+		}
+	}
+	if fallback != "" {
+		return fallback, nil
+	}
+	return "", &NotFoundError{APIError{StatusCode: http.StatusNotFound, Message: "no cover art for album " + album.Title}}
+}
+
+func (c *Client) fetchImage(ctx context.Context, rawURL string) ([]byte, string, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, rawURL, nil, "")
+	if err != nil {
+		return nil, "", err
+	}
+	resp, err := c.sendRetrying(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp); err != nil {
+		return nil, "", err
+	}
+	data, err := io.ReadAll(c.throttle(ctx, resp.Body))
+	if err != nil {
+		return nil, "", err
+	}
+	if len(data) == 0 {
+		return nil, "", &NotFoundError{APIError{StatusCode: http.StatusNotFound, Message: "empty cover art"}}
+	}
+	return data, imageContentType(resp.Header.Get("Content-Type"), data), nil
+}
+
+// AlbumCoverArt is one entry of a GetAlbumCoverArts batch.
+type AlbumCoverArt struct {
+	AlbumID     int
+	Data        []byte
+	ContentType string
+	Err         error
+}
+
+// GetAlbumCoverArts fetches artwork for several albums concurrently, bounded
+// by WithConcurrency. Per-album failures are reported in each entry's Err;
+// the returned error is only set when ctx ends the batch early.
+func (c *Client) GetAlbumCoverArts(ctx context.Context, albumIDs []int) ([]AlbumCoverArt, error) {
+	ctx = withDefaultRetryBudget(ctx)
+	out := make([]AlbumCoverArt, len(albumIDs))
+	err := runConcurrent(ctx, len(albumIDs), c.concurrencyLimit(), func(ctx context.Context, i int) error {
+		data, contentType, err := c.GetAlbumCoverArt(ctx, albumIDs[i])
+		out[i] = AlbumCoverArt{AlbumID: albumIDs[i], Data: data, ContentType: contentType, Err: err}
+		return nil
+	})
+	if err == nil {
+		err = ctx.Err()
+	}
+	return out, err
+}
@@ -0,0 +1,104 @@
+package juicewrld
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type Albums []Album
+
+type AlbumWithSongs struct {
+	Album
+	Songs Songs `json:"songs"`
+}
+
+// ArtistName returns the album artist's name, or "" when the API sent a
+// null or missing artist.
+func (a Album) ArtistName() string {
+	return a.Artist.Name
+}
+
+// ArtistID returns the album artist's ID, or 0 when the API sent a null or
+// missing artist.
+func (a Album) ArtistID() int {
+	return a.Artist.ID
+}
+
+func (a AlbumWithSongs) TotalDuration() (time.Duration, error) {
+	return sumDurations(a.Songs)
+}
+
+func (c *Client) GetAlbumWithSongs(ctx context.Context, albumID int) (AlbumWithSongs, error) {
+	ctx = withDefaultRetryBudget(ctx)
+	var (
+		wg       sync.WaitGroup
+		album    Album
+		songs    Songs
+		albumErr error
+		songsErr error
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		album, albumErr = c.GetAlbum(ctx, albumID)
+	}()
+	go func() {
+		defer wg.Done()
+		songs, songsErr = c.collectSongs(ctx, url.Values{"album": {strconv.Itoa(albumID)}})
+	}()
+	wg.Wait()
+
+	if albumErr != nil {
+		return AlbumWithSongs{}, albumErr
+	}
+	if songsErr != nil {
+		return AlbumWithSongs{}, songsErr
+	}
+	return AlbumWithSongs{Album: album, Songs: songs}, nil
+}
+
+func (a Album) WithSongs(ctx context.Context, c *Client) (AlbumWithSongs, error) {
+	songs, err := c.collectSongs(withDefaultRetryBudget(ctx), url.Values{"album": {strconv.Itoa(a.ID)}})
+	if err != nil {
+		return AlbumWithSongs{}, err
+	}
+	return AlbumWithSongs{Album: a, Songs: songs}, nil
+}
+
+// GetAlbumsSortedByDate returns GetAlbums sorted by release date, oldest
+// first unless desc is set. Albums without a release date come last.
+func (c *Client) GetAlbumsSortedByDate(ctx context.Context, desc bool) (Albums, error) {
+	albums, err := c.GetAlbums(ctx)
+	if err != nil {
+		return nil, err
+	}
+	order := Ascending
+	if desc {
+		order = Descending
+	}
+	SortAlbums(albums, SortKeyReleaseDate, order)
+	return albums, nil
+}
+
+func (c *Client) GetLatestAlbum(ctx context.Context) (Album, error) {
+	return c.firstDatedAlbum(ctx, true)
+}
+
+func (c *Client) GetOldestAlbum(ctx context.Context) (Album, error) {
+	return c.firstDatedAlbum(ctx, false)
+}
+
+func (c *Client) firstDatedAlbum(ctx context.Context, desc bool) (Album, error) {
+	albums, err := c.GetAlbumsSortedByDate(ctx, desc)
+	if err != nil {
+		return Album{}, err
+	}
+	if len(albums) == 0 || albums[0].ReleaseDate.IsZero() {
+		return Album{}, &NotFoundError{APIError{StatusCode: http.StatusNotFound, Message: "no album has a release date"}}
+	}
+	return albums[0], nil
+}
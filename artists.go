@@ -0,0 +1,102 @@
+package juicewrld
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// SongCount returns how many songs are credited to the artist, read from
+// the count of a one-song page rather than by fetching them all. As with
+// Era.SongCount, removed songs are included. On a server that ignores the
+// artist filter the count comes from the cached catalog, as in
+// GetArtistSongs. Nothing is cached on ar, which is a copy: a call costs
+// one request, or none once the catalog is cached.
+func (ar Artist) SongCount(ctx context.Context, c *Client) (int, error) {
+	ctx = withDefaultRetryBudget(ctx)
+	if ar.Name == "" {
+		full, err := c.GetArtist(ctx, ar.ID)
+		if err != nil {
+			return 0, err
+		}
+		ar = full
+	}
+	q := url.Values{"artist": {strconv.Itoa(ar.ID)}}
+	page, err := c.filteredPage(ctx, &c.artistFilter, q, 1, 1, ar.credits, ar.filter)
+	if err != nil {
+		return 0, err
+	}
+	return page.Count, nil
+}
+
+// GetArtistSongs returns a page of songs credited to the artist, using the
+// songs endpoint's artist filter. Servers that ignore the filter are
+// detected by results that don't credit the artist; the catalog is then
+// filtered client-side on CreditedArtists and paged locally, so the
+// response carries no next/previous links. The client remembers such a
+// server and pages the cached catalog from then on.
+func (c *Client) GetArtistSongs(ctx context.Context, artistID int, page, pageSize int) (PaginatedSongsResponse, error) {
+	ctx = withDefaultRetryBudget(ctx)
+	artist, err := c.GetArtist(ctx, artistID)
+	if err != nil {
+		return PaginatedSongsResponse{}, err
+	}
+	q := url.Values{"artist": {strconv.Itoa(artistID)}}
+	return c.filteredPage(ctx, &c.artistFilter, q, page, pageSize, artist.credits, artist.filter)
+}
+
+// Songs returns every song credited to the artist, with the same
+// client-side fallback as GetArtistSongs.
+func (ar Artist) Songs(ctx context.Context, c *Client) (Songs, error) {
+	ctx = withDefaultRetryBudget(ctx)
+	if ar.Name == "" {
+		full, err := c.GetArtist(ctx, ar.ID)
+		if err != nil {
+			return nil, err
+		}
+		ar = full
+	}
+	if supported, ok := c.artistFilter.known(); !ok || supported {
+		songs, err := c.collectSongs(ctx, url.Values{"artist": {strconv.Itoa(ar.ID)}})
+		if err != nil {
+			return songs, err
+		}
+		if len(songs) == 0 {
+			return songs, nil
+		}
+		honoured := ar.credits(songs)
+		c.artistFilter.set(honoured)
+		if honoured {
+			return songs, nil
+		}
+	}
+	all, err := c.cachedCatalog(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.exclude(nil, ar.filter(all)), nil
+}
+
+// credits reports whether every song names the artist in its credits,
+// which is how a server honouring the artist filter responds.
+func (ar Artist) credits(songs Songs) bool {
+	name := foldString(ar.Name)
+	for _, s := range songs {
+		if !containsFolded(s.CreditedArtists, name) {
+			return false
+		}
+	}
+	return true
+}
+
+// filter keeps the songs that credit the artist.
+func (ar Artist) filter(songs Songs) Songs {
+	name := foldString(ar.Name)
+	var out Songs
+	for _, s := range songs {
+		if containsFolded(s.CreditedArtists, name) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -0,0 +1,122 @@
+package juicewrld
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestGetArtistSongsRemembersIgnoredFilter(t *testing.T) {
+	songs := []map[string]interface{}{
+		{"id": 1, "name": "Lucid Dreams", "credited_artists": "Juice WRLD"},
+		{"id": 2, "name": "Other Song", "credited_artists": "Someone Else"},
+		{"id": 3, "name": "Robbery", "credited_artists": "Juice WRLD"},
+	}
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"/juicewrld/songs/":     jsonHandler(map[string]interface{}{"count": len(songs), "results": songs}),
+		"/juicewrld/artists/1/": jsonHandler(map[string]interface{}{"id": 1, "name": "Juice WRLD"}),
+	})
+	ctx := context.Background()
+	c := api.client()
+
+	for page, want := range [][]int{{1}, {3}, nil} {
+		out, err := c.GetArtistSongs(ctx, 1, page+1, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(ids(out.Results), want) || out.Count != 2 {
+			t.Errorf("page %d = %v count %d, want %v count 2", page+1, ids(out.Results), out.Count, want)
+		}
+	}
+	all, err := Artist{ID: 1, Name: "Juice WRLD"}.Songs(ctx, c)
+	if err != nil || !reflect.DeepEqual(ids(all), []int{1, 3}) {
+		t.Errorf("Songs = %v, %v", ids(all), err)
+	}
+	if n := api.hitCount("/juicewrld/songs/"); n != 2 {
+		t.Errorf("%d song requests, want one filtered page and one catalog scan", n)
+	}
+	if !c.CacheStats().Catalog.Warm {
+		t.Error("catalog cache not warm after the fallback")
+	}
+}
+
+func TestGetArtistSongsUsesHonouredFilter(t *testing.T) {
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"/juicewrld/songs/": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("artist") != "1" {
+				t.Errorf("songs requested without the artist filter: %s", r.URL.RawQuery)
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{"count": 1, "results": []map[string]interface{}{
+				{"id": 1, "name": "Lucid Dreams", "credited_artists": "Juice WRLD"},
+			}})
+		},
+		"/juicewrld/artists/1/": jsonHandler(map[string]interface{}{"id": 1, "name": "Juice WRLD"}),
+	})
+	ctx := context.Background()
+	c := api.client()
+	for i := 0; i < 2; i++ {
+		out, err := c.GetArtistSongs(ctx, 1, 1, 0)
+		if err != nil || !reflect.DeepEqual(ids(out.Results), []int{1}) {
+			t.Errorf("GetArtistSongs = %v, %v", ids(out.Results), err)
+		}
+	}
+	if c.CacheStats().Catalog.Warm {
+		t.Error("catalog scanned although the server honours the filter")
+	}
+}
+
+func TestArtistSongCount(t *testing.T) {
+	songs := []map[string]interface{}{
+		{"id": 1, "name": "Lucid Dreams", "credited_artists": "Juice WRLD"},
+		{"id": 2, "name": "Other Song", "credited_artists": "Someone Else"},
+		{"id": 3, "name": "Robbery", "credited_artists": "Juice WRLD"},
+		{"id": 4, "name": "Taken Down", "credited_artists": "Juice WRLD", "removed": true},
+	}
+	artist := jsonHandler(map[string]interface{}{"id": 1, "name": "Juice WRLD"})
+
+	t.Run("filter honoured", func(t *testing.T) {
+		api := newFakeAPI(t, map[string]http.HandlerFunc{
+			"/juicewrld/songs/": func(w http.ResponseWriter, r *http.Request) {
+				if q := r.URL.Query(); q.Get("artist") != "1" || q.Get("page_size") != "1" {
+					t.Errorf("songs requested with %s, want artist=1 and page_size=1", r.URL.RawQuery)
+				}
+				writeJSON(w, http.StatusOK, map[string]interface{}{"count": 3, "results": songs[:1]})
+			},
+			"/juicewrld/artists/1/": artist,
+		})
+		n, err := Artist{ID: 1}.SongCount(context.Background(), api.client())
+		if err != nil || n != 3 {
+			t.Errorf("SongCount = %d, %v; want the server's count of 3", n, err)
+		}
+		if n := api.hitCount("/juicewrld/songs/"); n != 1 {
+			t.Errorf("%d song requests, want 1", n)
+		}
+	})
+
+	t.Run("filter ignored", func(t *testing.T) {
+		api := newFakeAPI(t, map[string]http.HandlerFunc{
+			"/juicewrld/songs/":     jsonHandler(map[string]interface{}{"count": len(songs), "results": songs}),
+			"/juicewrld/artists/1/": artist,
+		})
+		ctx := context.Background()
+		c := api.client()
+		ar := Artist{ID: 1, Name: "Juice WRLD"}
+		for i := 0; i < 2; i++ {
+			if n, err := ar.SongCount(ctx, c); err != nil || n != 3 {
+				t.Errorf("SongCount = %d, %v; want 3 credited songs, the removed one included", n, err)
+			}
+		}
+		page, err := c.GetArtistSongs(ctx, 1, 1, 0)
+		if err != nil || page.Count != 3 || page.Dropped() != 1 || !reflect.DeepEqual(ids(page.Results), []int{1, 3}) {
+			t.Errorf("GetArtistSongs = %v count %d dropped %d, %v", ids(page.Results), page.Count, page.Dropped(), err)
+		}
+		all, err := ar.Songs(ctx, c)
+		if err != nil || !reflect.DeepEqual(ids(all), []int{1, 3}) {
+			t.Errorf("Songs = %v, %v", ids(all), err)
+		}
+		if n := api.hitCount("/juicewrld/songs/"); n != 2 {
+			t.Errorf("%d song requests, want one filtered page and one catalog scan", n)
+		}
+	})
+}
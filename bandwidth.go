@@ -0,0 +1,87 @@
+package juicewrld
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+// minBandwidthBurst keeps small limits from throttling every short read.
+const minBandwidthBurst = 32 << 10
+
+// WithBandwidthLimit caps the combined rate of download and stream bodies
+// read, and upload bodies sent, at bytesPerSec. The budget is shared by every transfer on the
+// client, so DownloadFiles stays under the limit regardless of its
+// concurrency.
+func WithBandwidthLimit(bytesPerSec int64) Option {
+	return func(c *Client) {
+		if bytesPerSec <= 0 {
+			c.bandwidth = nil
+			return
+		}
+		burst := max(bytesPerSec, minBandwidthBurst)
+		c.bandwidth = &bandwidthLimiter{
+			rate:   float64(bytesPerSec),
+			burst:  float64(burst),
+			tokens: float64(burst),
+		}
+	}
+}
+
+type bandwidthLimiter struct {
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// reserve takes n bytes from the bucket and returns how long the caller must
+// wait before the bytes are paid for. The balance may go negative, which
+// queues later callers behind this one.
+func (l *bandwidthLimiter) reserve(now time.Time, n int) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.last.IsZero() {
+		l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+	l.tokens -= float64(n)
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+type throttledBody struct {
+	io.ReadCloser
+	ctx context.Context
+	c   *Client
+}
+
+func (b *throttledBody) Read(p []byte) (int, error) {
+	if limit := int(b.c.bandwidth.burst); len(p) > limit {
+		p = p[:limit]
+	}
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		if d := b.c.bandwidth.reserve(b.c.now(), n); d > 0 {
+			select {
+			case <-b.c.after(d):
+			case <-b.ctx.Done():
+				return n, b.ctx.Err()
+			}
+		}
+	}
+	return n, err
+}
+
+// throttle wraps body in the client's bandwidth limiter, if one is set.
+func (c *Client) throttle(ctx context.Context, body io.ReadCloser) io.ReadCloser {
+	if c.bandwidth == nil {
+		return body
+	}
+	return &throttledBody{ReadCloser: body, ctx: ctx, c: c}
+}
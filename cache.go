@@ -0,0 +1,212 @@
+package juicewrld
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const defaultLookupTTL = 10 * time.Minute
+
+type cachedList[T any] struct {
+	mu        sync.Mutex
+	items     []T
+	fetchedAt time.Time
+}
+
+func (l *cachedList[T]) get(ctx context.Context, clock Clock, ttl time.Duration, fetch func(context.Context) ([]T, error)) ([]T, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.fetchedAt.IsZero() && clock.Now().Sub(l.fetchedAt) < ttl {
+		return l.items, nil
+	}
+	items, err := fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	l.items = items
+	l.fetchedAt = clock.Now()
+	return items, nil
+}
+
+func (l *cachedList[T]) stats(now time.Time) CacheEntryStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.fetchedAt.IsZero() {
+		return CacheEntryStats{}
+	}
+	return CacheEntryStats{
+		Warm:      true,
+		Items:     len(l.items),
+		FetchedAt: l.fetchedAt,
+		Age:       now.Sub(l.fetchedAt),
+	}
+}
+
+type CacheEntryStats struct {
+	Warm      bool          `json:"warm"`
+	Items     int           `json:"items"`
+	FetchedAt time.Time     `json:"fetched_at"`
+	Age       time.Duration `json:"age"`
+}
+
+type CacheStats struct {
+	Artists    CacheEntryStats `json:"artists"`
+	Albums     CacheEntryStats `json:"albums"`
+	Eras       CacheEntryStats `json:"eras"`
+	Categories CacheEntryStats `json:"categories"`
+	Stats      CacheEntryStats `json:"stats"`
+	Catalog    CacheEntryStats `json:"catalog"`
+	SongPages  int             `json:"song_pages"`
+	Songs      int             `json:"songs"`
+}
+
+func (c *Client) CacheStats() CacheStats {
+	now := c.now()
+	return CacheStats{
+		Artists:    c.artistsCache.stats(now),
+		Albums:     c.albumsCache.stats(now),
+		Eras:       c.erasCache.stats(now),
+		Categories: c.categoriesCache.stats(now),
+		Stats:      c.statsCache.stats(now),
+		Catalog:    c.catalogCache.stats(now),
+		SongPages:  c.songPageCache.len(),
+		Songs:      c.songCache.Len(),
+	}
+}
+
+// WarmUp concurrently fetches artists, albums, eras, categories and stats
+// into the in-memory caches. Each cache warms independently, so one failing
+// endpoint doesn't stop the others, and rather than only the first error
+// every failure is returned, joined. Caches already warm are not fetched
+// again, so WarmUp is idempotent and safe to call concurrently. Call it at
+// startup together with WithCache so later Get calls are cache hits.
+func (c *Client) WarmUp(ctx context.Context) error {
+	tasks := []struct {
+		name string
+		fn   func(context.Context) error
+	}{
+		{"eras", func(ctx context.Context) error { _, err := c.cachedEras(ctx); return err }},
+		{"categories", func(ctx context.Context) error { _, err := c.cachedCategories(ctx); return err }},
+		{"artists", func(ctx context.Context) error { _, err := c.cachedArtists(ctx); return err }},
+		{"albums", func(ctx context.Context) error { _, err := c.cachedAlbums(ctx); return err }},
+		{"stats", func(ctx context.Context) error { _, err := c.cachedStats(ctx); return err }},
+	}
+	ctx = withDefaultRetryBudget(ctx)
+	errs := make([]error, len(tasks))
+	var wg sync.WaitGroup
+	for i, t := range tasks {
+		wg.Add(1)
+		go func(i int, name string, fn func(context.Context) error) {
+			defer wg.Done()
+			if err := fn(ctx); err != nil {
+				errs[i] = fmt.Errorf("warm up %s: %w", name, err)
+			}
+		}(i, t.name, t.fn)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// WarmUpSongs runs WarmUp and also caches the first maxPages pages of the
+// default song listing, as returned by GetSongs without filters.
+func (c *Client) WarmUpSongs(ctx context.Context, maxPages int) error {
+	if err := c.WarmUp(ctx); err != nil {
+		return err
+	}
+	ctx = withDefaultRetryBudget(ctx)
+	for page := 1; page <= maxPages; page++ {
+		var resp PaginatedSongsResponse
+		q := url.Values{"page": {strconv.Itoa(page)}}
+		if err := c.getPage(ctx, "/juicewrld/songs/", q, &resp); err != nil {
+			return fmt.Errorf("warm up songs page %d: %w", page, err)
+		}
+		c.songPageCache.put(page, resp, c.now())
+		if resp.Next == nil || len(resp.Results) == 0 {
+			break
+		}
+	}
+	return nil
+}
+
+func (c *Client) cachedArtists(ctx context.Context) ([]Artist, error) {
+	return c.artistsCache.get(ctx, c.clock, c.lookupTTL, c.fetchArtists)
+}
+
+func (c *Client) cachedCategories(ctx context.Context) ([]map[string]interface{}, error) {
+	return c.categoriesCache.get(ctx, c.clock, c.lookupTTL, c.fetchCategories)
+}
+
+func (c *Client) cachedAlbums(ctx context.Context) ([]Album, error) {
+	return c.albumsCache.get(ctx, c.clock, c.lookupTTL, c.fetchAlbums)
+}
+
+func (c *Client) cachedEras(ctx context.Context) ([]Era, error) {
+	return c.erasCache.get(ctx, c.clock, c.lookupTTL, c.fetchEras)
+}
+
+func (c *Client) cachedStats(ctx context.Context) (Stats, error) {
+	items, err := c.statsCache.get(ctx, c.clock, c.lookupTTL, func(ctx context.Context) ([]Stats, error) {
+		st, err := c.fetchStats(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return []Stats{st}, nil
+	})
+	if err != nil {
+		return Stats{}, err
+	}
+	return items[0], nil
+}
+
+// cachedCatalog is every song, for the client-side fallbacks of server
+// filters the server ignores. Removed songs are kept, and marked, so local
+// pages can count them as the server's pages do; callers drop them.
+func (c *Client) cachedCatalog(ctx context.Context) (Songs, error) {
+	return c.catalogCache.get(ctx, c.clock, c.lookupTTL, func(ctx context.Context) ([]Song, error) {
+		songs, err := NewPaginator[Song](c, "/juicewrld/songs/", nil).All(ctx)
+		return c.exclude(&SongFilter{IncludeRemoved: true}, songs), err
+	})
+}
+
+type songPageCache struct {
+	mu    sync.Mutex
+	pages map[int]cachedSongPage
+}
+
+type cachedSongPage struct {
+	resp      PaginatedSongsResponse
+	fetchedAt time.Time
+}
+
+func (p *songPageCache) get(page int, now time.Time, ttl time.Duration) (PaginatedSongsResponse, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	e, ok := p.pages[page]
+	if !ok || now.Sub(e.fetchedAt) >= ttl {
+		return PaginatedSongsResponse{}, false
+	}
+	resp := e.resp
+	resp.Results = slices.Clone(resp.Results)
+	return resp, true
+}
+
+func (p *songPageCache) put(page int, resp PaginatedSongsResponse, now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.pages == nil {
+		p.pages = make(map[int]cachedSongPage)
+	}
+	p.pages[page] = cachedSongPage{resp: resp, fetchedAt: now}
+}
+
+func (p *songPageCache) len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.pages)
+}
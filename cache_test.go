@@ -0,0 +1,111 @@
+package juicewrld
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWarmUpWarmsEachCacheIndependently(t *testing.T) {
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"/juicewrld/artists/":    statusHandler(http.StatusInternalServerError),
+		"/juicewrld/albums/":     statusHandler(http.StatusInternalServerError),
+		"/juicewrld/eras/":       jsonHandler(map[string]interface{}{"count": 1, "results": []Era{{ID: 1, Name: "DRFL"}}}),
+		"/juicewrld/categories/": jsonHandler(map[string]interface{}{"categories": []map[string]string{{"value": "released"}}}),
+		"/juicewrld/stats/":      jsonHandler(Stats{TotalSongs: 3}),
+	})
+	c := api.client(WithCache(time.Minute))
+
+	err := c.WarmUp(context.Background())
+	if err == nil {
+		t.Fatal("WarmUp succeeded with two failing endpoints")
+	}
+	for _, name := range []string{"artists", "albums"} {
+		if !strings.Contains(err.Error(), "warm up "+name) {
+			t.Errorf("error %q does not report the %s failure", err, name)
+		}
+	}
+	st := c.CacheStats()
+	if !st.Eras.Warm || !st.Categories.Warm || !st.Stats.Warm {
+		t.Errorf("healthy caches not warmed after a sibling failed: %+v", st)
+	}
+	if st.Artists.Warm || st.Albums.Warm {
+		t.Errorf("failing caches reported warm: %+v", st)
+	}
+}
+
+func TestWarmUpIsIdempotentUnderConcurrency(t *testing.T) {
+	paths := []string{"/juicewrld/artists/", "/juicewrld/albums/", "/juicewrld/eras/", "/juicewrld/categories/", "/juicewrld/stats/"}
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"/juicewrld/artists/":    jsonHandler(map[string]interface{}{"count": 1, "results": []Artist{{ID: 1, Name: "Juice WRLD"}}}),
+		"/juicewrld/albums/":     jsonHandler(map[string]interface{}{"count": 1, "results": []Album{{ID: 1, Title: "Legends Never Die"}}}),
+		"/juicewrld/eras/":       jsonHandler(map[string]interface{}{"count": 1, "results": []Era{{ID: 1, Name: "DRFL"}}}),
+		"/juicewrld/categories/": jsonHandler(map[string]interface{}{"categories": []map[string]string{{"value": "released"}}}),
+		"/juicewrld/stats/":      jsonHandler(Stats{TotalSongs: 3}),
+	})
+	c := api.client(WithCache(time.Minute))
+
+	var wg sync.WaitGroup
+	errs := make([]error, 8)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = c.WarmUp(context.Background())
+		}(i)
+	}
+	wg.Wait()
+	if err := c.WarmUp(context.Background()); err != nil {
+		errs = append(errs, err)
+	}
+	for _, err := range errs {
+		if err != nil {
+			t.Errorf("WarmUp = %v", err)
+		}
+	}
+	for _, p := range paths {
+		if n := api.hitCount(p); n != 1 {
+			t.Errorf("%s fetched %d times, want once", p, n)
+		}
+	}
+	st := c.CacheStats()
+	for name, e := range map[string]CacheEntryStats{"artists": st.Artists, "albums": st.Albums, "eras": st.Eras, "categories": st.Categories, "stats": st.Stats} {
+		if !e.Warm || e.Items != 1 {
+			t.Errorf("%s cache = %+v, want warm with one item", name, e)
+		}
+	}
+}
+
+func TestLookupCacheExpiresOnClientClock(t *testing.T) {
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"/juicewrld/eras/": jsonHandler(map[string]interface{}{"count": 1, "results": []Era{{ID: 1, Name: "DRFL"}}}),
+	})
+	clock := &manualClock{at: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	c := api.client(WithCache(time.Minute), WithClock(clock))
+	ctx := context.Background()
+
+	if _, err := c.cachedEras(ctx); err != nil {
+		t.Fatal(err)
+	}
+	clock.advance(30 * time.Second)
+	if _, err := c.cachedEras(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if n := api.hitCount("/juicewrld/eras/"); n != 1 {
+		t.Errorf("eras fetched %d times within the TTL, want once", n)
+	}
+	if age := c.CacheStats().Eras.Age; age != 30*time.Second {
+		t.Errorf("eras cache age = %v, want 30s on the injected clock", age)
+	}
+
+	clock.advance(time.Minute)
+	if _, err := c.cachedEras(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if n := api.hitCount("/juicewrld/eras/"); n != 2 {
+		t.Errorf("eras fetched %d times after the TTL, want twice", n)
+	}
+}
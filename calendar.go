@@ -0,0 +1,113 @@
+package juicewrld
+
+import (
+	"sort"
+	"time"
+)
+
+// MonthDay is a day of the year, independent of the year.
+type MonthDay struct {
+	Month time.Month
+	Day   int
+}
+
+const (
+	CalendarAlbum = "album"
+	CalendarSong  = "song"
+)
+
+// CalendarEntry is one release in a Calendar.
+type CalendarEntry struct {
+	Kind     string
+	ID       int
+	Title    string
+	Released time.Time
+	// Anniversary is the date On or Upcoming matched the entry on; it is
+	// zero in the Calendar itself.
+	Anniversary time.Time
+}
+
+// YearsAgo returns how many years before Anniversary the release was.
+func (e CalendarEntry) YearsAgo() int {
+	return e.Anniversary.Year() - e.Released.Year()
+}
+
+// Calendar groups releases by the day of the year they came out on.
+type Calendar map[MonthDay][]CalendarEntry
+
+// BuildReleaseCalendar indexes albums by ReleaseDate and songs by their
+// parsed release date. Entries without a known day (including year- or
+// month-only song dates) are skipped.
+func BuildReleaseCalendar(albums []Album, songs []Song) Calendar {
+	cal := Calendar{}
+	add := func(e CalendarEntry) {
+		md := MonthDay{Month: e.Released.Month(), Day: e.Released.Day()}
+		cal[md] = append(cal[md], e)
+	}
+	for _, a := range albums {
+		if !a.ReleaseDate.IsZero() {
+			add(CalendarEntry{Kind: CalendarAlbum, ID: a.ID, Title: a.Title, Released: a.ReleaseDate.Time})
+		}
+	}
+	for _, s := range songs {
+		if t, ok := parseLooseDay(s.ReleaseDate); ok {
+			add(CalendarEntry{Kind: CalendarSong, ID: s.ID, Title: s.Name, Released: t})
+		}
+	}
+	for _, entries := range cal {
+		sortCalendarEntries(entries)
+	}
+	return cal
+}
+
+// On returns the releases whose anniversary falls on t's date, oldest
+// first, leaving out releases after t's year. On February 28 of a
+// non-leap year, February 29 releases are included.
+func (cal Calendar) On(t time.Time) []CalendarEntry {
+	days := []MonthDay{{t.Month(), t.Day()}}
+	if t.Month() == time.February && t.Day() == 28 && !isLeapYear(t.Year()) {
+		days = append(days, MonthDay{time.February, 29})
+	}
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	var out []CalendarEntry
+	for _, md := range days {
+		for _, e := range cal[md] {
+			if e.Released.Year() <= t.Year() {
+				e.Anniversary = day
+				out = append(out, e)
+			}
+		}
+	}
+	sortCalendarEntries(out)
+	return out
+}
+
+// Upcoming returns the anniversaries in the days days starting at from's
+// date, ordered by date and then as On orders them.
+func (cal Calendar) Upcoming(from time.Time, days int) []CalendarEntry {
+	var out []CalendarEntry
+	for i := 0; i < days; i++ {
+		out = append(out, cal.On(from.AddDate(0, 0, i))...)
+	}
+	return out
+}
+
+func sortCalendarEntries(entries []CalendarEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if !a.Released.Equal(b.Released) {
+			return a.Released.Before(b.Released)
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Title != b.Title {
+			return a.Title < b.Title
+		}
+		return a.ID < b.ID
+	})
+}
+
+func isLeapYear(y int) bool {
+	return y%4 == 0 && (y%100 != 0 || y%400 == 0)
+}
@@ -0,0 +1,145 @@
+package juicewrld
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func calendarAlbums(t *testing.T) []Album {
+	t.Helper()
+	var albums []Album
+	err := json.Unmarshal([]byte(`[
+		{"id": 1, "title": "Goodbye & Good Riddance", "release_date": "2018-05-23"},
+		{"id": 2, "title": "Death Race for Love", "release_date": "2019-03-08T00:00:00Z"},
+		{"id": 3, "title": "Legends Never Die", "release_date": "2020-07-10"},
+		{"id": 4, "title": "Unscheduled", "release_date": null},
+		{"id": 5, "title": "Tokyo Release", "release_date": "2019-12-08T00:30:00+09:00"}
+	]`), &albums)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return albums
+}
+
+var calendarSongs = []Song{
+	{ID: 10, Name: "Leap Day Leak", ReleaseDate: "2020-02-29"},
+	{ID: 11, Name: "Lucid Dreams", ReleaseDate: "May 23, 2018"},
+	{ID: 12, Name: "All Girls Are The Same", ReleaseDate: "05/23/2018"},
+	{ID: 13, Name: "Year Only", ReleaseDate: "2019"},
+	{ID: 14, Name: "Month Only", ReleaseDate: "March 2019"},
+	{ID: 15, Name: "Unknown", ReleaseDate: "TBD"},
+	{ID: 16, Name: "Blank"},
+	{ID: 17, Name: "New Year", ReleaseDate: "2021-01-01"},
+	{ID: 18, Name: "New Year's Eve", ReleaseDate: "2019-12-31"},
+}
+
+type calendarHit struct {
+	ID       int
+	YearsAgo int
+	On       string
+}
+
+func hits(entries []CalendarEntry) []calendarHit {
+	var out []calendarHit
+	for _, e := range entries {
+		out = append(out, calendarHit{e.ID, e.YearsAgo(), e.Anniversary.Format("2006-01-02")})
+	}
+	return out
+}
+
+func TestBuildReleaseCalendarSkipsUnknownDays(t *testing.T) {
+	cal := BuildReleaseCalendar(calendarAlbums(t), calendarSongs)
+	var n int
+	for _, entries := range cal {
+		for _, e := range entries {
+			n++
+			if e.ID == 4 || (e.ID >= 13 && e.ID <= 16) {
+				t.Errorf("entry %d (%s) has no known day", e.ID, e.Title)
+			}
+		}
+	}
+	if n != 9 {
+		t.Errorf("calendar holds %d entries, want 9", n)
+	}
+	if got := cal[MonthDay{time.December, 8}]; len(got) != 1 || got[0].ID != 5 {
+		t.Errorf("Dec 8 = %+v, want the album keyed on its own local date", got)
+	}
+}
+
+func TestCalendarOn(t *testing.T) {
+	cal := BuildReleaseCalendar(calendarAlbums(t), calendarSongs)
+	tests := []struct {
+		name string
+		day  time.Time
+		want []calendarHit
+	}{
+		{"same day ordered by kind then title", date(2025, time.May, 23), []calendarHit{
+			{1, 7, "2025-05-23"}, {12, 7, "2025-05-23"}, {11, 7, "2025-05-23"},
+		}},
+		{"release year itself", date(2020, time.July, 10), []calendarHit{{3, 0, "2020-07-10"}}},
+		{"before the release", date(2019, time.July, 10), nil},
+		{"leap day in a leap year", date(2024, time.February, 29), []calendarHit{{10, 4, "2024-02-29"}}},
+		{"Feb 28 in a leap year", date(2024, time.February, 28), nil},
+		{"Feb 28 in a common year", date(2025, time.February, 28), []calendarHit{{10, 5, "2025-02-28"}}},
+		{"Mar 1 in a common year", date(2025, time.March, 1), nil},
+		{"century common year", date(2100, time.February, 28), []calendarHit{{10, 80, "2100-02-28"}}},
+		{"century leap year", date(2400, time.February, 28), nil},
+		{"time of day dropped", time.Date(2026, time.March, 8, 23, 59, 0, 0, time.UTC), []calendarHit{{2, 7, "2026-03-08"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := hits(cal.On(tt.day))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("On(%s) = %+v, want %+v", tt.day.Format("2006-01-02"), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalendarUpcoming(t *testing.T) {
+	cal := BuildReleaseCalendar(calendarAlbums(t), calendarSongs)
+	tests := []struct {
+		name string
+		from time.Time
+		days int
+		want []calendarHit
+	}{
+		{"across the new year", date(2025, time.December, 30), 4, []calendarHit{
+			{18, 6, "2025-12-31"}, {17, 5, "2026-01-01"},
+		}},
+		{"end of a common February", date(2027, time.February, 27), 3, []calendarHit{{10, 7, "2027-02-28"}}},
+		{"end of a leap February", date(2028, time.February, 27), 3, []calendarHit{{10, 8, "2028-02-29"}}},
+		{"from is included", date(2025, time.May, 23), 1, []calendarHit{
+			{1, 7, "2025-05-23"}, {12, 7, "2025-05-23"}, {11, 7, "2025-05-23"},
+		}},
+		{"zero days", date(2025, time.May, 23), 0, nil},
+		{"negative days", date(2025, time.May, 23), -3, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := hits(cal.Upcoming(tt.from, tt.days))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Upcoming(%s, %d) = %+v, want %+v", tt.from.Format("2006-01-02"), tt.days, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalendarUpcomingAcrossDSTChange(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	cal := BuildReleaseCalendar(calendarAlbums(t), calendarSongs)
+	from := time.Date(2026, time.March, 7, 0, 30, 0, 0, loc)
+	got := cal.Upcoming(from, 3)
+	if len(got) != 1 || got[0].ID != 2 || !got[0].Anniversary.Equal(time.Date(2026, time.March, 8, 0, 0, 0, 0, loc)) {
+		t.Errorf("Upcoming across DST = %+v", got)
+	}
+}
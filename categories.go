@@ -0,0 +1,86 @@
+package juicewrld
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Category is one entry of GetCategories: Value is what the songs
+// endpoint's category filter and Song.Category use, Label the display name.
+type Category struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+}
+
+func categoryFromMap(m map[string]interface{}) Category {
+	str := func(keys ...string) string {
+		for _, k := range keys {
+			if v, ok := m[k]; ok && v != nil {
+				return fmt.Sprint(v)
+			}
+		}
+		return ""
+	}
+	return Category{Value: str("value", "name", "id"), Label: str("label", "name", "value")}
+}
+
+type CategoryWithCount struct {
+	Category
+	SongCount int `json:"song_count"`
+}
+
+// Percentage returns SongCount as a percentage of total, or 0 when total is
+// not positive.
+func (c CategoryWithCount) Percentage(total int) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return float64(c.SongCount) * 100 / float64(total)
+}
+
+// GetCategoriesWithCounts returns GetCategories, in order, with each
+// category's song count from GetStats. Stats are matched on the category's
+// value or label, ignoring case; categories the stats don't mention count
+// zero songs. Use Stats.TotalSongs as the total for Percentage.
+func (c *Client) GetCategoriesWithCounts(ctx context.Context) ([]CategoryWithCount, error) {
+	ctx = withDefaultRetryBudget(ctx)
+	var (
+		wg       sync.WaitGroup
+		cats     []map[string]interface{}
+		stats    Stats
+		catsErr  error
+		statsErr error
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		cats, catsErr = c.GetCategories(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		stats, statsErr = c.GetStats(ctx)
+	}()
+	wg.Wait()
+
+	if catsErr != nil {
+		return nil, catsErr
+	}
+	if statsErr != nil {
+		return nil, statsErr
+	}
+	counts := make(map[string]int, len(stats.CategoryStats))
+	for name, n := range stats.CategoryStats {
+		counts[foldString(name)] += n
+	}
+	out := make([]CategoryWithCount, len(cats))
+	for i, m := range cats {
+		cat := categoryFromMap(m)
+		n, ok := counts[foldString(cat.Value)]
+		if !ok {
+			n = counts[foldString(cat.Label)]
+		}
+		out[i] = CategoryWithCount{Category: cat, SongCount: n}
+	}
+	return out, nil
+}
@@ -7,9 +7,17 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
+	"math/rand"
 	"net/http"
 	"net/url"
 	"os"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -20,20 +28,105 @@ type Client struct {
 	HTTPClient *http.Client
 	userAgent  string
 	timeout    time.Duration
+
+	maxRetries     int
+	retryBaseDelay time.Duration
+	jitter         JitterStrategy
+	backoff        func(attempt int) time.Duration
+	clock          Clock
+
+	base atomic.Pointer[parsedBaseURL]
+
+	lookupTTL       time.Duration
+	artistsCache    cachedList[Artist]
+	albumsCache     cachedList[Album]
+	erasCache       cachedList[Era]
+	categoriesCache cachedList[map[string]interface{}]
+	statsCache      cachedList[Stats]
+	songPageCache   songPageCache
+	catalogCache    cachedList[Song]
+	songCache       *SongCache
+	cacheReads      bool
+
+	zipWatches zipWatchSet
+	zipDedup   zipDedup
+	albumArt   albumArtCache
+
+	pageKeys PaginationKeys
+
+	verifySamples int
+	rngMu         sync.Mutex
+	rng           *rand.Rand
+
+	journal *journal
+
+	concurrency int
+	bandwidth   *bandwidthLimiter
+	latency     *latencyRing
+
+	pins   []string
+	signer RequestSigner
+
+	profile         Profile
+	removedStatuses map[string]bool
+
+	sanitizeStrings    bool
+	strictContentType  bool
+	connRetry          bool
+	queryNormalization bool
+	webBaseURL         string
+	offlineDir         string
+
+	modifiedSince      filterSupport
+	artistFilter       filterSupport
+	instrumentalFilter filterSupport
+	// serverPageSize is the server's default page size, seen on an
+	// unsized page while falling back from an ignored filter.
+	serverPageSize atomic.Int32
+	syncSnapshot   syncSnapshot
 }
 
-func New(baseURL string) *Client {
+type parsedBaseURL struct {
+	raw string
+	url *url.URL
+}
+
+func New(baseURL string, opts ...Option) *Client {
 	if baseURL == "" {
 		baseURL = "https://juicewrldapi.com"
 	}
-	return &Client{
+	c := &Client{
 		BaseURL: baseURL,
 		HTTPClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		userAgent: "JuiceWRLD-API-Wrapper-Go/" + goWrapperVersion,
-		timeout:   30 * time.Second,
-	}
+		userAgent:          "JuiceWRLD-API-Wrapper-Go/" + goWrapperVersion,
+		timeout:            30 * time.Second,
+		retryBaseDelay:     defaultRetryBaseDelay,
+		clock:              realClock{},
+		lookupTTL:          defaultLookupTTL,
+		profile:            OfficialProfile,
+		connRetry:          true,
+		queryNormalization: true,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.cacheReads {
+		c.songCache = NewSongCache(c.lookupTTL)
+		c.songCache.clock = c.clock
+	}
+	c.installOffline()
+	c.installPinning()
+	c.installSigner()
+	c.installConnectionRetry()
+	c.installLatencyTracking()
+	if c.journal != nil {
+		hc := *c.HTTPClient
+		hc.Transport = &journalTransport{base: hc.Transport, journal: c.journal, clock: c.clock}
+		c.HTTPClient = &hc
+	}
+	return c
 }
 
 func (c *Client) CloseIdleConnections() {
@@ -42,32 +135,56 @@ func (c *Client) CloseIdleConnections() {
 	}
 }
 
+func (c *Client) Close() error {
+	c.CloseIdleConnections()
+	if c.journal != nil {
+		c.journal.close()
+	}
+	return nil
+}
+
 func (c *Client) do(ctx context.Context, method, path string, query url.Values, body interface{}, out interface{}) error {
-	u, err := url.Parse(c.BaseURL)
-	if err != nil {
+	if _, err := c.baseURL(); err != nil {
 		return err
 	}
-	u.Path = u.ResolveReference(&url.URL{Path: path}).Path
-	if query != nil {
-		u.RawQuery = query.Encode()
-	}
-
-	var reqBody io.Reader
+	var payload []byte
 	if body != nil {
-		buf, err := json.Marshal(body)
+		var err error
+		payload, err = json.Marshal(body)
 		if err != nil {
 			return err
 		}
-		reqBody = bytes.NewBuffer(buf)
+	}
+	return c.doURL(ctx, method, c.endpointURL(path, query), payload, body != nil, out)
+}
+
+func (c *Client) doURL(ctx context.Context, method, rawURL string, payload []byte, hasBody bool, out interface{}) error {
+	for attempt := 0; ; attempt++ {
+		err := c.doOnce(withAttempt(ctx, attempt), method, rawURL, payload, hasBody, out)
+		if err == nil {
+			return nil
+		}
+		delay, ok := c.retryDelay(ctx, method, attempt, err)
+		if !ok {
+			return err
+		}
+		if err := c.waitRetry(ctx, delay, err); err != nil {
+			return err
+		}
+	}
+}
+
+func (c *Client) doOnce(ctx context.Context, method, rawURL string, payload []byte, hasBody bool, out interface{}) error {
+	var reqBody io.Reader
+	if hasBody {
+		reqBody = bytes.NewReader(payload)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, u.String(), reqBody)
+	req, err := c.newRequest(ctx, method, rawURL, reqBody, "application/json")
 	if err != nil {
 		return err
 	}
-	req.Header.Set("User-Agent", c.userAgent)
-	req.Header.Set("Accept", "application/json")
-	if body != nil {
+	if hasBody {
 		req.Header.Set("Content-Type", "application/json")
 	}
 
@@ -76,30 +193,105 @@ func (c *Client) do(ctx context.Context, method, path string, query url.Values,
 		return err
 	}
 	defer resp.Body.Close()
+	observeServerDate(ctx, resp)
 
-	if resp.StatusCode == http.StatusTooManyRequests {
-		b, _ := io.ReadAll(resp.Body)
-		return &RateLimitError{APIError{StatusCode: resp.StatusCode, Message: string(b)}}
+	if err := checkResponse(resp); err != nil {
+		return err
 	}
-	if resp.StatusCode == http.StatusNotFound {
-		b, _ := io.ReadAll(resp.Body)
-		return &NotFoundError{APIError{StatusCode: resp.StatusCode, Message: string(b)}}
+
+	if out == nil {
+		io.Copy(io.Discard, resp.Body)
+		return nil
 	}
-	if resp.StatusCode == http.StatusUnauthorized {
-		b, _ := io.ReadAll(resp.Body)
-		return &AuthenticationError{APIError{StatusCode: resp.StatusCode, Message: string(b)}}
+	// Small responses of known length are read into a pooled buffer, so
+	// polling does not allocate a decoder each time; anything else is
+	// decoded as it streams in rather than held in memory whole.
+	if resp.ContentLength < 0 || resp.ContentLength > maxPooledBufferSize {
+		return c.decode(resp.Body, out)
 	}
-	if resp.StatusCode >= 400 {
-		b, _ := io.ReadAll(resp.Body)
-		return &APIError{StatusCode: resp.StatusCode, Message: string(b)}
+	buf := getBuffer()
+	defer putBuffer(buf)
+	if _, err := buf.ReadFrom(resp.Body); err != nil {
+		return err
 	}
+	return c.unmarshal(buf.Bytes(), out)
+}
 
-	if out == nil {
-		io.Copy(io.Discard, resp.Body)
+func (c *Client) baseURL() (*url.URL, error) {
+	if p := c.base.Load(); p != nil && p.raw == c.BaseURL {
+		return p.url, nil
+	}
+	raw := c.BaseURL
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	c.base.Store(&parsedBaseURL{raw: raw, url: u})
+	return u, nil
+}
+
+const maxPooledBufferSize = 1 << 20
+
+var bufferPool = sync.Pool{
+	New: func() interface{} { return new(bytes.Buffer) },
+}
+
+func getBuffer() *bytes.Buffer {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBufferSize {
+		return
+	}
+	bufferPool.Put(buf)
+}
+
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode < 400 {
 		return nil
 	}
-	dec := json.NewDecoder(resp.Body)
-	return dec.Decode(out)
+	b, _ := io.ReadAll(resp.Body)
+	apiErr := APIError{StatusCode: resp.StatusCode, Message: string(b)}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return &RateLimitError{apiErr}
+	case http.StatusNotFound:
+		return &NotFoundError{apiErr}
+	case http.StatusUnauthorized:
+		return &AuthenticationError{apiErr}
+	case http.StatusConflict:
+		return &ConflictError{apiErr}
+	}
+	return &apiErr
+}
+
+var errNilContext = errors.New("juicewrld: nil context")
+
+// newRequest builds every outgoing request, so sub-requests made by composite
+// helpers always inherit the caller's context for cancellation and deadlines.
+func (c *Client) newRequest(ctx context.Context, method, rawURL string, body io.Reader, accept string) (*http.Request, error) {
+	if ctx == nil {
+		return nil, errNilContext
+	}
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	setAccept(ctx, req, accept)
+	if c.signer != nil {
+		return hashBody(req)
+	}
+	return req, nil
+}
+
+func setAccept(ctx context.Context, req *http.Request, def string) {
+	if accept := acceptFrom(ctx, def); accept != "" {
+		req.Header.Set("Accept", accept)
+	}
 }
 
 func (c *Client) get(ctx context.Context, path string, query url.Values, out interface{}) error {
@@ -124,10 +316,18 @@ func (c *Client) GetAPIOverview(ctx context.Context) (map[string]interface{}, er
 }
 
 func (c *Client) GetArtists(ctx context.Context) ([]Artist, error) {
+	if c.cacheReads {
+		items, err := c.cachedArtists(ctx)
+		return slices.Clone(items), err
+	}
+	return c.fetchArtists(ctx)
+}
+
+func (c *Client) fetchArtists(ctx context.Context) ([]Artist, error) {
 	var raw struct {
 		Results []Artist `json:"results"`
 	}
-	if err := c.get(ctx, "/juicewrld/artists/", nil, &raw); err != nil {
+	if err := c.getPage(ctx, "/juicewrld/artists/", nil, &raw); err != nil {
 		return nil, err
 	}
 	return raw.Results, nil
@@ -140,10 +340,18 @@ func (c *Client) GetArtist(ctx context.Context, artistID int) (Artist, error) {
 }
 
 func (c *Client) GetAlbums(ctx context.Context) ([]Album, error) {
+	if c.cacheReads {
+		items, err := c.cachedAlbums(ctx)
+		return slices.Clone(items), err
+	}
+	return c.fetchAlbums(ctx)
+}
+
+func (c *Client) fetchAlbums(ctx context.Context) ([]Album, error) {
 	var raw struct {
 		Results []Album `json:"results"`
 	}
-	if err := c.get(ctx, "/juicewrld/albums/", nil, &raw); err != nil {
+	if err := c.getPage(ctx, "/juicewrld/albums/", nil, &raw); err != nil {
 		return nil, err
 	}
 	return raw.Results, nil
@@ -169,39 +377,50 @@ func (c *Client) GetSongs(ctx context.Context, page int, category, era, search *
 	if era != nil && *era != "" {
 		q.Set("era", *era)
 	}
-	if search != nil && *search != "" {
-		q.Set("search", *search)
-	}
-
-	var raw map[string]interface{}
-	if err := c.get(ctx, "/juicewrld/songs/", q, &raw); err != nil {
-		return PaginatedSongsResponse{}, err
+	if search != nil {
+		if s := c.normalizeSearch(*search); s != "" {
+			q.Set("search", s)
+		}
 	}
 
-	if _, ok := raw["results"]; !ok {
-		b, _ := json.Marshal(raw)
-		return PaginatedSongsResponse{}, errors.New(string(b))
+	// Only the default listing is cached; WarmUpSongs is what fills it.
+	cacheable := c.cacheReads && pageSize <= 0 &&
+		q.Get("category") == "" && q.Get("era") == "" && q.Get("search") == ""
+	if cacheable {
+		if out, ok := c.songPageCache.get(max(page, 1), c.now(), c.lookupTTL); ok {
+			c.excludePage(nil, &out)
+			return out, nil
+		}
 	}
-
 	var out PaginatedSongsResponse
-	buf, _ := json.Marshal(raw)
-	if err := json.Unmarshal(buf, &out); err != nil {
+	if err := c.getPage(ctx, "/juicewrld/songs/", q, &out); err != nil {
 		return PaginatedSongsResponse{}, err
 	}
+	out.pageSize = max(pageSize, 0)
+	c.excludePage(nil, &out)
 	return out, nil
 }
 
 func (c *Client) GetSong(ctx context.Context, songID int) (Song, error) {
 	var out Song
 	err := c.get(ctx, fmt.Sprintf("/juicewrld/songs/%d/", songID), nil, &out)
+	c.markRemoved(&out)
 	return out, err
 }
 
 func (c *Client) GetEras(ctx context.Context) ([]Era, error) {
+	if c.cacheReads {
+		items, err := c.cachedEras(ctx)
+		return slices.Clone(items), err
+	}
+	return c.fetchEras(ctx)
+}
+
+func (c *Client) fetchEras(ctx context.Context) ([]Era, error) {
 	var raw struct {
 		Results []Era `json:"results"`
 	}
-	if err := c.get(ctx, "/juicewrld/eras/", nil, &raw); err != nil {
+	if err := c.getPage(ctx, "/juicewrld/eras/", nil, &raw); err != nil {
 		return nil, err
 	}
 	return raw.Results, nil
@@ -214,12 +433,40 @@ func (c *Client) GetEra(ctx context.Context, eraID int) (Era, error) {
 }
 
 func (c *Client) GetStats(ctx context.Context) (Stats, error) {
+	if c.cacheReads {
+		st, err := c.cachedStats(ctx)
+		st.CategoryStats = maps.Clone(st.CategoryStats)
+		st.EraStats = maps.Clone(st.EraStats)
+		return st, err
+	}
+	return c.fetchStats(ctx)
+}
+
+func (c *Client) fetchStats(ctx context.Context) (Stats, error) {
 	var out Stats
 	err := c.get(ctx, "/juicewrld/stats/", nil, &out)
 	return out, err
 }
 
+func (c *Client) GetStatsInto(ctx context.Context, dst *Stats) error {
+	if dst == nil {
+		return &ValidationError{APIError{Message: "nil Stats destination"}}
+	}
+	dst.TotalSongs = 0
+	clear(dst.CategoryStats)
+	clear(dst.EraStats)
+	return c.get(ctx, "/juicewrld/stats/", nil, dst)
+}
+
 func (c *Client) GetCategories(ctx context.Context) ([]map[string]interface{}, error) {
+	if c.cacheReads {
+		items, err := c.cachedCategories(ctx)
+		return slices.Clone(items), err
+	}
+	return c.fetchCategories(ctx)
+}
+
+func (c *Client) fetchCategories(ctx context.Context) ([]map[string]interface{}, error) {
 	var out struct {
 		Categories []map[string]interface{} `json:"categories"`
 	}
@@ -249,6 +496,7 @@ func (c *Client) GetJuiceWRLDSong(ctx context.Context, songID int) (map[string]i
 }
 
 func (c *Client) PlayJuiceWRLDSong(ctx context.Context, songID int) (map[string]interface{}, error) {
+	ctx = withDefaultRetryBudget(ctx)
 	songData, err := c.GetJuiceWRLDSong(ctx, songID)
 	if err != nil {
 		return nil, err
@@ -261,17 +509,10 @@ func (c *Client) PlayJuiceWRLDSong(ctx context.Context, songID int) (map[string]
 	if fileStr == "" {
 		return map[string]interface{}{"error": "Invalid file URL format", "song_id": songID, "status": "invalid_url"}, nil
 	}
-	idx := -1
-	for i := 0; i+6 <= len(fileStr); i++ {
-		if fileStr[i:i+7] == "/media/" {
-			idx = i
-			break
-		}
-	}
-	if idx == -1 {
+	filePath, ok := mediaPath(fileStr)
+	if !ok {
 		return map[string]interface{}{"error": "Invalid file URL format", "song_id": songID, "status": "invalid_url"}, nil
 	}
-	filePath := fileStr[idx+7:]
 
 	possiblePaths := []string{
 		fmt.Sprintf("Compilation/1. Released Discography/%v/%v.mp3", songData["album"], songData["title"]),
@@ -281,27 +522,18 @@ func (c *Client) PlayJuiceWRLDSong(ctx context.Context, songID int) (map[string]
 	}
 
 	for _, p := range possiblePaths {
-		streamURL := fmt.Sprintf("%s/juicewrld/files/download/?path=%s", c.BaseURL, url.QueryEscape(p))
-		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, streamURL, nil)
-		req.Header.Set("Range", "bytes=0-0")
-		resp, err := c.HTTPClient.Do(req)
-		if err == nil && (resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusPartialContent) {
-			ct := resp.Header.Get("content-type")
-			resp.Body.Close()
+		if info, ok := c.probeStream(ctx, p); ok {
 			return map[string]interface{}{
 				"status":       "success",
 				"song_id":      songID,
-				"stream_url":   streamURL,
+				"stream_url":   info.StreamURL,
 				"file_path":    p,
-				"content_type": ct,
+				"content_type": info.ContentType,
 			}, nil
 		}
-		if resp != nil {
-			resp.Body.Close()
-		}
 	}
 
-	streamURL := fmt.Sprintf("%s/juicewrld/files/download/?path=%s", c.BaseURL, url.QueryEscape(filePath))
+	streamURL := c.downloadURL(filePath)
 	return map[string]interface{}{
 		"status":     "file_not_found_but_url_provided",
 		"song_id":    songID,
@@ -311,11 +543,139 @@ func (c *Client) PlayJuiceWRLDSong(ctx context.Context, songID int) (map[string]
 	}, nil
 }
 
-func (c *Client) StreamAudioFile(ctx context.Context, filePath string) (map[string]interface{}, error) {
-	streamURL := fmt.Sprintf("%s/juicewrld/files/download/?path=%s", c.BaseURL, url.QueryEscape(filePath))
-	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, streamURL, nil)
+// ResolveBestAudio finds an audio file for the song in the archive's usual
+// locations. With prefer set, the first extension in prefer that exists at
+// any location wins. Otherwise every IsPlayable format is probed and
+// PreferredSource picks among the files found.
+func (c *Client) ResolveBestAudio(ctx context.Context, songID int, prefer []string) (StreamInfo, error) {
+	ctx = withDefaultRetryBudget(ctx)
+	songData, err := c.GetJuiceWRLDSong(ctx, songID)
+	if err != nil {
+		return StreamInfo{}, err
+	}
+	// A song without an album has a null album, which must not be probed
+	// as a folder named after it.
+	album, _ := songData["album"].(string)
+	title, _ := songData["title"].(string)
+	if title != "" {
+		if info, ok := c.resolveAudio(ctx, audioPathBases(album, title), prefer); ok {
+			info.SongID = songID
+			return info, nil
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return StreamInfo{}, err
+	}
+	return StreamInfo{}, &NotFoundError{APIError{Message: fmt.Sprintf("no audio file found for song %d", songID)}}
+}
+
+// audioPathBases lists where the archive keeps audio for a title, without
+// the file extension, in the order playback tries them.
+func audioPathBases(album, title string) []string {
+	var bases []string
+	if album != "" {
+		bases = append(bases, fmt.Sprintf("Compilation/1. Released Discography/%s/%s", album, title))
+	}
+	return append(bases,
+		fmt.Sprintf("Compilation/2. Unreleased Discography/%s", title),
+		fmt.Sprintf("Snippets/%s/%s", title, title),
+		fmt.Sprintf("Session Edits/%s", title),
+	)
+}
+
+// resolveAudio probes every base with each preferred extension and returns
+// the first file that exists. Without a preference it probes every playable
+// format at every base and lets PreferredSource choose.
+func (c *Client) resolveAudio(ctx context.Context, bases, prefer []string) (StreamInfo, bool) {
+	if len(prefer) == 0 {
+		return c.resolvePreferredAudio(ctx, bases)
+	}
+	for _, ext := range prefer {
+		ext = strings.TrimPrefix(strings.ToLower(ext), ".")
+		for _, base := range bases {
+			if info, ok := c.probeStream(ctx, base+"."+ext); ok {
+				return info, true
+			}
+		}
+	}
+	return StreamInfo{}, false
+}
+
+func (c *Client) resolvePreferredAudio(ctx context.Context, bases []string) (StreamInfo, bool) {
+	var paths []string
+	for _, base := range bases {
+		for _, f := range playableFormats {
+			paths = append(paths, base+"."+f.ext)
+		}
+	}
+	found := make([]StreamInfo, len(paths))
+	runConcurrent(ctx, len(paths), defaultConcurrency, func(ctx context.Context, i int) error {
+		found[i], _ = c.probeStream(ctx, paths[i])
+		return nil
+	})
+	var files []FileInfo
+	byPath := map[string]StreamInfo{}
+	for _, info := range found {
+		if info.FilePath == "" {
+			continue
+		}
+		files = append(files, FileInfo{Name: path.Base(info.FilePath), Type: "file", Path: info.FilePath, Size: info.size})
+		byPath[info.FilePath] = info
+	}
+	best := PreferredSource(files)
+	info, ok := byPath[best.Path]
+	return info, ok
+}
+
+func (c *Client) probeStream(ctx context.Context, filePath string) (StreamInfo, bool) {
+	streamURL := c.downloadURL(filePath)
+	req, err := c.newRequest(ctx, http.MethodGet, streamURL, nil, "")
+	if err != nil {
+		return StreamInfo{}, false
+	}
 	req.Header.Set("Range", "bytes=0-0")
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.sendRetrying(req)
+	if err != nil {
+		return StreamInfo{}, false
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		return StreamInfo{}, false
+	}
+	supportsRange := resp.Header.Get("accept-ranges")
+	return StreamInfo{
+		Status:        "success",
+		StreamURL:     streamURL,
+		FilePath:      filePath,
+		ContentType:   resp.Header.Get("content-type"),
+		ContentLength: resp.Header.Get("content-length"),
+		SupportsRange: supportsRange != "" && supportsRange != "none",
+		size:          probedSize(resp),
+	}, true
+}
+
+// probedSize is the full size of the file behind a bytes=0-0 probe: the
+// total from Content-Range, or the length of a full response. It is -1
+// when neither says.
+func probedSize(resp *http.Response) int64 {
+	if resp.StatusCode == http.StatusPartialContent {
+		_, total, ok := strings.Cut(resp.Header.Get("Content-Range"), "/")
+		if n, err := strconv.ParseInt(total, 10, 64); ok && err == nil {
+			return n
+		}
+		return -1
+	}
+	return resp.ContentLength
+}
+
+func (c *Client) StreamAudioFile(ctx context.Context, filePath string, params ...url.Values) (map[string]interface{}, error) {
+	streamURL := c.downloadURL(filePath, params...)
+	req, err := c.newRequest(ctx, http.MethodGet, streamURL, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Range", "bytes=0-0")
+	resp, err := c.sendRetrying(req)
 	if err != nil {
 		return map[string]interface{}{"error": fmt.Sprintf("Request failed: %v", err), "file_path": filePath, "status": "request_error"}, nil
 	}
@@ -340,16 +700,25 @@ func (c *Client) StreamAudioFile(ctx context.Context, filePath string) (map[stri
 func (c *Client) BrowseFiles(ctx context.Context, path string, search *string) (DirectoryInfo, error) {
 	q := url.Values{}
 	if path != "" {
-		q.Set("path", path)
+		q.Set("path", c.normalizePath(path))
 	}
-	if search != nil && *search != "" {
-		q.Set("search", *search)
+	if search != nil {
+		if s := c.normalizeSearch(*search); s != "" {
+			q.Set("search", s)
+		}
 	}
 	var out DirectoryInfo
 	err := c.get(ctx, "/juicewrld/files/browse/", q, &out)
 	return out, err
 }
 
+func (c *Client) BrowseParent(ctx context.Context, d DirectoryInfo) (DirectoryInfo, error) {
+	if d.IsRoot() {
+		return DirectoryInfo{}, &ValidationError{APIError{Message: "directory has no parent"}}
+	}
+	return c.BrowseFiles(ctx, d.Parent(), nil)
+}
+
 func (c *Client) GetFileInfo(ctx context.Context, filePath string) (FileInfo, error) {
 	q := url.Values{"path": {filePath}}
 	var out FileInfo
@@ -357,71 +726,108 @@ func (c *Client) GetFileInfo(ctx context.Context, filePath string) (FileInfo, er
 	return out, err
 }
 
-func (c *Client) DownloadFile(ctx context.Context, filePath string) ([]byte, error) {
-	u := fmt.Sprintf("%s/juicewrld/files/download/?path=%s", c.BaseURL, url.QueryEscape(filePath))
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("User-Agent", c.userAgent)
-	resp, err := c.HTTPClient.Do(req)
+// DownloadFile fetches a file into memory. params are merged into the query
+// as for DownloadURL.
+func (c *Client) DownloadFile(ctx context.Context, filePath string, params ...url.Values) ([]byte, error) {
+	body, err := c.openDownload(ctx, filePath, params...)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode >= 400 {
-		b, _ := io.ReadAll(resp.Body)
-		return nil, &APIError{StatusCode: resp.StatusCode, Message: string(b)}
-	}
-	return io.ReadAll(resp.Body)
+	defer body.Close()
+	return io.ReadAll(body)
 }
 
+// DownloadFileTo streams a file to savePath, through a temporary file
+// that is renamed into place once the download completes.
 func (c *Client) DownloadFileTo(ctx context.Context, filePath, savePath string) (string, error) {
-	data, err := c.DownloadFile(ctx, filePath)
+	body, err := c.openDownload(ctx, filePath)
 	if err != nil {
 		return "", err
 	}
-	if err := writeFileAtomic(savePath, data); err != nil {
+	defer body.Close()
+	if err := writeFileAtomic(savePath, body); err != nil {
 		return "", err
 	}
+	if c.verifySamples > 0 {
+		if err := c.VerifyDownload(ctx, filePath, savePath, c.verifySamples); err != nil {
+			return savePath, err
+		}
+	}
 	return savePath, nil
 }
 
-func writeFileAtomic(path string, data []byte) error {
+// openDownload starts a download and returns its throttled body.
+func (c *Client) openDownload(ctx context.Context, filePath string, params ...url.Values) (io.ReadCloser, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.downloadURL(filePath, params...), nil, "")
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.sendRetrying(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		b, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, &APIError{StatusCode: resp.StatusCode, Message: string(b)}
+	}
+	if err := c.checkDownloadType(resp, filePath); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return c.throttle(ctx, resp.Body), nil
+}
+
+func writeFileAtomic(path string, r io.Reader) error {
 	tmp := path + ".tmp"
-	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, r)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp)
 		return err
 	}
 	return os.Rename(tmp, path)
 }
 
-func (c *Client) GetCoverArt(ctx context.Context, filePath string) ([]byte, error) {
-	u := fmt.Sprintf("%s/juicewrld/files/cover-art/?path=%s", c.BaseURL, url.QueryEscape(filePath))
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+// GetCoverArt returns the cover art embedded in an audio file together with
+// its content type. When the server sends no Content-Type, or only
+// application/octet-stream, it is sniffed from the image bytes. params are merged into the query as for
+// DownloadURL.
+func (c *Client) GetCoverArt(ctx context.Context, filePath string, params ...url.Values) (data []byte, contentType string, err error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.coverArtURL(filePath, params...), nil, "")
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
-	req.Header.Set("User-Agent", c.userAgent)
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.sendRetrying(req)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode >= 400 {
 		b, _ := io.ReadAll(resp.Body)
-		return nil, &APIError{StatusCode: resp.StatusCode, Message: string(b)}
+		return nil, "", &APIError{StatusCode: resp.StatusCode, Message: string(b)}
 	}
-	return io.ReadAll(resp.Body)
+	data, err = io.ReadAll(c.throttle(ctx, resp.Body))
+	if err != nil {
+		return nil, "", err
+	}
+	return data, imageContentType(resp.Header.Get("Content-Type"), data), nil
 }
 
 func (c *Client) CreateZip(ctx context.Context, filePaths []string) ([]byte, error) {
-	u := fmt.Sprintf("%s/juicewrld/files/zip-selection/", c.BaseURL)
+	u := c.endpointURL("/juicewrld/files/zip-selection/", nil)
 	reqBody := map[string]interface{}{"paths": filePaths}
 	buf, err := json.Marshal(reqBody)
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(buf))
+	req, err := c.newRequest(ctx, http.MethodPost, u, bytes.NewReader(buf), "")
 	if err != nil {
 		return nil, err
 	}
@@ -435,10 +841,17 @@ func (c *Client) CreateZip(ctx context.Context, filePaths []string) ([]byte, err
 		b, _ := io.ReadAll(resp.Body)
 		return nil, &APIError{StatusCode: resp.StatusCode, Message: string(b)}
 	}
-	return io.ReadAll(resp.Body)
+	return io.ReadAll(c.throttle(ctx, resp.Body))
 }
 
 func (c *Client) StartZipJob(ctx context.Context, filePaths []string) (string, error) {
+	if c.zipDedup.window > 0 {
+		return c.zipDedup.start(ctx, c.now(), filePaths, c.startZipJob)
+	}
+	return c.startZipJob(ctx, filePaths)
+}
+
+func (c *Client) startZipJob(ctx context.Context, filePaths []string) (string, error) {
 	var out struct {
 		JobID string `json:"job_id"`
 	}
@@ -456,23 +869,30 @@ func (c *Client) GetZipJobStatus(ctx context.Context, jobID string) (map[string]
 }
 
 func (c *Client) CancelZipJob(ctx context.Context, jobID string) (bool, error) {
-	var out map[string]interface{}
-	err := c.post(ctx, fmt.Sprintf("/juicewrld/cancel-zip-job/%s/", url.PathEscape(jobID)), nil, &out)
+	res, err := c.CancelZipJobResult(ctx, jobID)
 	if err != nil {
 		return false, err
 	}
-	return true, nil
+	return res.Cancelled, nil
 }
 
 func (c *Client) SearchSongs(ctx context.Context, query string, category *string, year *int, tags []string, limit int, offset int) (SearchResult, error) {
-	page := 1
+	original := query
+	query = c.normalizeSearch(query)
+	if query == "" {
+		return SearchResult{}, &ValidationError{APIError{Message: "search query must not be empty"}}
+	}
+	q := url.Values{"search": {query}}
+	// Without a limit the server picks its own page size, so an offset
+	// cannot be mapped onto a page and the first page is returned.
+	effectiveOffset := 0
 	if limit > 0 {
-		page = (offset / limit) + 1
-	}
-	q := url.Values{
-		"search":    {query},
-		"page_size": {fmt.Sprintf("%d", limit)},
-		"page":      {fmt.Sprintf("%d", page)},
+		q.Set("page_size", strconv.Itoa(limit))
+		if offset > 0 {
+			page := offset/limit + 1
+			effectiveOffset = (page - 1) * limit
+			q.Set("page", strconv.Itoa(page))
+		}
 	}
 	if category != nil && *category != "" {
 		q.Set("category", *category)
@@ -492,13 +912,16 @@ func (c *Client) SearchSongs(ctx context.Context, query string, category *string
 	}
 
 	var raw PaginatedSongsResponse
-	if err := c.get(ctx, "/juicewrld/songs/", q, &raw); err != nil {
+	if err := c.getPage(ctx, "/juicewrld/songs/", q, &raw); err != nil {
 		return SearchResult{}, err
 	}
+	c.excludePage(nil, &raw)
 	res := SearchResult{
-		Songs:     raw.Results,
-		Total:     raw.Count,
-		QueryTime: "0ms",
+		Query:           original,
+		Songs:           raw.Results,
+		Total:           raw.Count,
+		EffectiveOffset: effectiveOffset,
+		QueryTime:       "0ms",
 	}
 	if category != nil {
 		res.Category = category
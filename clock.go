@@ -0,0 +1,29 @@
+package juicewrld
+
+import "time"
+
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+func WithClock(clk Clock) Option {
+	return func(c *Client) {
+		if clk != nil {
+			c.clock = clk
+		}
+	}
+}
+
+func (c *Client) now() time.Time {
+	return c.clock.Now()
+}
+
+func (c *Client) after(d time.Duration) <-chan time.Time {
+	return c.clock.After(d)
+}
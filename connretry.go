@@ -0,0 +1,78 @@
+package juicewrld
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"syscall"
+)
+
+// WithConnectionRetry controls the single immediate retry of idempotent
+// requests that fail with a connection reset or unexpected EOF before any
+// response arrives, the usual symptom of reusing a keep-alive connection
+// the server has already closed. It is on by default and independent of
+// WithRetry; pass false to disable it.
+func WithConnectionRetry(enabled bool) Option {
+	return func(c *Client) { c.connRetry = enabled }
+}
+
+// installConnectionRetry wraps the transport, above the signer so each
+// attempt is signed afresh. A client without its own transport gets a
+// private copy of the default one, so dropping idle connections before a
+// retry never touches other clients in the process.
+func (c *Client) installConnectionRetry() {
+	if !c.connRetry || c.offlineDir != "" {
+		return
+	}
+	hc := *c.HTTPClient
+	base := hc.Transport
+	if base == nil {
+		base = http.DefaultTransport.(*http.Transport).Clone()
+	}
+	hc.Transport = &connRetryTransport{base: base}
+	c.HTTPClient = &hc
+}
+
+// connRetryTransport sits at the transport level so downloads, probes and
+// streams, which call HTTPClient.Do directly, are covered as well as do.
+type connRetryTransport struct {
+	base http.RoundTripper
+}
+
+func (t *connRetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	resp, err := base.RoundTrip(req)
+	if err == nil || !isConnReset(err) || !isIdempotent(req.Method) || req.Context().Err() != nil {
+		return resp, err
+	}
+	if !spendRetry(req.Context()) {
+		return resp, &BudgetExhaustedError{Err: err}
+	}
+	retry := req.Clone(req.Context())
+	if req.Body != nil && req.Body != http.NoBody {
+		if req.GetBody == nil {
+			return resp, err
+		}
+		body, berr := req.GetBody()
+		if berr != nil {
+			return resp, err
+		}
+		retry.Body = body
+	}
+	// The other idle connections to the host are likely just as stale, so
+	// drop them to make the retry dial afresh.
+	t.CloseIdleConnections()
+	return base.RoundTrip(retry)
+}
+
+func (t *connRetryTransport) CloseIdleConnections() {
+	if ci, ok := t.base.(interface{ CloseIdleConnections() }); ok {
+		ci.CloseIdleConnections()
+	}
+}
+
+func isConnReset(err error) bool {
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF)
+}
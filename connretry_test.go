@@ -0,0 +1,150 @@
+package juicewrld
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// resetFirstListener accepts connections normally except the first, which
+// it reads the start of the TLS handshake from and then resets.
+type resetFirstListener struct {
+	net.Listener
+	accepted atomic.Int32
+}
+
+func (l *resetFirstListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+		if l.accepted.Add(1) > 1 {
+			return conn, nil
+		}
+		buf := make([]byte, 16)
+		conn.Read(buf)
+		if tcp, ok := conn.(*net.TCPConn); ok {
+			tcp.SetLinger(0)
+		}
+		conn.Close()
+	}
+}
+
+func newResetFirstServer(t *testing.T) (*httptest.Server, *resetFirstListener) {
+	t.Helper()
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 7, "name": "Lucid Dreams"}`))
+	}))
+	l := &resetFirstListener{Listener: srv.Listener}
+	srv.Listener = l
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+	return srv, l
+}
+
+func TestConnectionRetryAfterHandshakeReset(t *testing.T) {
+	srv, l := newResetFirstServer(t)
+	c := New(srv.URL, WithHTTPClient(srv.Client()))
+
+	song, err := c.GetSong(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("GetSong: %v", err)
+	}
+	if song.Name != "Lucid Dreams" {
+		t.Errorf("song name = %q", song.Name)
+	}
+	if n := l.accepted.Load(); n != 2 {
+		t.Errorf("connections = %d, want 2", n)
+	}
+}
+
+func TestConnectionRetryCoversDownloads(t *testing.T) {
+	srv, l := newResetFirstServer(t)
+	c := New(srv.URL, WithHTTPClient(srv.Client()))
+
+	if _, err := c.DownloadFile(context.Background(), "a.mp3"); err != nil {
+		t.Fatalf("DownloadFile: %v", err)
+	}
+	if n := l.accepted.Load(); n != 2 {
+		t.Errorf("connections = %d, want 2", n)
+	}
+}
+
+func TestConnectionRetryDisabled(t *testing.T) {
+	srv, l := newResetFirstServer(t)
+	c := New(srv.URL, WithHTTPClient(srv.Client()), WithConnectionRetry(false))
+
+	if _, err := c.GetSong(context.Background(), 7); err == nil {
+		t.Fatal("GetSong succeeded, want the reset to surface")
+	}
+	if n := l.accepted.Load(); n != 1 {
+		t.Errorf("connections = %d, want 1", n)
+	}
+}
+
+func TestConnectionRetryChargesRetryBudget(t *testing.T) {
+	srv, l := newResetFirstServer(t)
+	c := New(srv.URL, WithHTTPClient(srv.Client()))
+	ctx := WithRetryBudget(context.Background(), 0, 0)
+
+	_, err := c.GetSong(ctx, 7)
+	var exhausted *BudgetExhaustedError
+	if !errors.As(err, &exhausted) {
+		t.Fatalf("GetSong = %v, want a BudgetExhaustedError", err)
+	}
+	if n := l.accepted.Load(); n != 1 {
+		t.Errorf("connections = %d, want 1", n)
+	}
+}
+
+func TestConnectionRetryResignsEachAttempt(t *testing.T) {
+	srv, _ := newResetFirstServer(t)
+	var signed atomic.Int32
+	signer := func(req *http.Request, _ []byte) error {
+		signed.Add(1)
+		return nil
+	}
+	c := New(srv.URL, WithHTTPClient(srv.Client()), WithRequestSigner(signer))
+
+	if _, err := c.GetSong(context.Background(), 7); err != nil {
+		t.Fatalf("GetSong: %v", err)
+	}
+	if n := signed.Load(); n != 2 {
+		t.Errorf("signer ran %d times, want once per attempt (2)", n)
+	}
+}
+
+func TestConnectionRetryCloseIdleStaysOnWrappedTransport(t *testing.T) {
+	var closed atomic.Int32
+	base := &closeCountingTransport{closed: &closed}
+	c := New("http://example.invalid", WithHTTPClient(&http.Client{Transport: base}))
+	c.CloseIdleConnections()
+	if closed.Load() != 1 {
+		t.Errorf("CloseIdleConnections reached the wrapped transport %d times, want 1", closed.Load())
+	}
+
+	c = New("http://example.invalid")
+	rt, ok := c.HTTPClient.Transport.(*connRetryTransport)
+	if !ok {
+		t.Fatalf("transport = %T, want *connRetryTransport", c.HTTPClient.Transport)
+	}
+	if rt.base == http.DefaultTransport {
+		t.Error("connection retry wraps http.DefaultTransport; closing idle connections would affect the whole process")
+	}
+}
+
+type closeCountingTransport struct {
+	closed *atomic.Int32
+}
+
+func (t *closeCountingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, net.ErrClosed
+}
+
+func (t *closeCountingTransport) CloseIdleConnections() { t.closed.Add(1) }
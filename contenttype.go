@@ -0,0 +1,29 @@
+package juicewrld
+
+import (
+	"mime"
+	"net/http"
+)
+
+// WithStrictContentType makes downloads fail with
+// *UnexpectedContentTypeError when the server answers with text/html. That
+// is almost always a BaseURL pointing at a website instead of the API,
+// which would otherwise hand back the page as file bytes. It is off by
+// default so genuine HTML files can still be downloaded.
+func WithStrictContentType() Option {
+	return func(c *Client) { c.strictContentType = true }
+}
+
+// checkDownloadType enforces WithStrictContentType on a successful download
+// response.
+func (c *Client) checkDownloadType(resp *http.Response, filePath string) error {
+	if !c.strictContentType {
+		return nil
+	}
+	ct := resp.Header.Get("Content-Type")
+	mediaType, _, err := mime.ParseMediaType(ct)
+	if err != nil || mediaType != "text/html" {
+		return nil
+	}
+	return &UnexpectedContentTypeError{Path: filePath, URL: resp.Request.URL.String(), ContentType: ct}
+}
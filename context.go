@@ -0,0 +1,16 @@
+package juicewrld
+
+import "context"
+
+type acceptKey struct{}
+
+func WithAccept(ctx context.Context, accept string) context.Context {
+	return context.WithValue(ctx, acceptKey{}, accept)
+}
+
+func acceptFrom(ctx context.Context, def string) string {
+	if v, ok := ctx.Value(acceptKey{}).(string); ok && v != "" {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,108 @@
+package juicewrld
+
+import (
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// TestNoDetachedContexts guards against sub-requests that ignore the
+// caller's context: the package must not create root contexts, and every
+// request must be built through Client.newRequest.
+func TestNoDetachedContexts(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fset := token.NewFileSet()
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, name, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil {
+				continue
+			}
+			ast.Inspect(fn.Body, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				sel, ok := call.Fun.(*ast.SelectorExpr)
+				if !ok {
+					return true
+				}
+				pkg, ok := sel.X.(*ast.Ident)
+				if !ok {
+					return true
+				}
+				switch pkg.Name + "." + sel.Sel.Name {
+				case "context.Background", "context.TODO":
+					t.Errorf("%s: %s creates a root context; use the caller's ctx", fset.Position(call.Pos()), pkg.Name+"."+sel.Sel.Name)
+				case "http.NewRequest", "http.Get", "http.Head", "http.Post", "http.PostForm":
+					t.Errorf("%s: %s.%s sends without the caller's context; use Client.newRequest", fset.Position(call.Pos()), pkg.Name, sel.Sel.Name)
+				case "http.NewRequestWithContext":
+					if fn.Name.Name != "newRequest" {
+						t.Errorf("%s: build requests with Client.newRequest, not http.NewRequestWithContext", fset.Position(call.Pos()))
+					}
+				}
+				return true
+			})
+		}
+	}
+}
+
+type ctxMarkerKey struct{}
+
+// TestCompositeHelpersPropagateContext runs helpers that fan out into
+// several requests and checks every request carries the caller's context.
+func TestCompositeHelpersPropagateContext(t *testing.T) {
+	var requests atomic.Int32
+	stub := RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requests.Add(1)
+		if req.Context().Value(ctxMarkerKey{}) == nil {
+			t.Errorf("%s %s sent without the caller's context", req.Method, req.URL)
+		}
+		body := `{"id": 1, "name": "Lucid Dreams", "album": "Goodbye & Good Riddance", "title": "Lucid Dreams", "results": []}`
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})
+	c := New("http://api.invalid", WithHTTPClient(&http.Client{Transport: stub}))
+	ctx := context.WithValue(context.Background(), ctxMarkerKey{}, true)
+
+	calls := map[string]func() error{
+		"PlayJuiceWRLDSong": func() error { _, err := c.PlayJuiceWRLDSong(ctx, 1); return err },
+		"ResolveBestAudio":  func() error { _, err := c.ResolveBestAudio(ctx, 1, nil); return err },
+		"StreamAudioFile":   func() error { _, err := c.StreamAudioFile(ctx, "a.mp3"); return err },
+		"GetAlbumWithSongs": func() error { _, err := c.GetAlbumWithSongs(ctx, 1); return err },
+		"GetEraWithSongs":   func() error { _, err := c.GetEraWithSongs(ctx, 1); return err },
+		"GetSongsWithDetails": func() error {
+			_, err := c.GetSongsWithDetails(ctx, nil)
+			return err
+		},
+		"WarmUp": func() error { return c.WarmUp(ctx) },
+	}
+	for name, call := range calls {
+		before := requests.Load()
+		call()
+		if requests.Load() == before {
+			t.Errorf("%s sent no requests", name)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package juicewrld
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const coverArtHeaderBytes = 64 << 10
+
+type ImageInfo struct {
+	Format string `json:"format"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+func (c *Client) coverArtURL(filePath string, params ...url.Values) string {
+	return c.endpointURL("/juicewrld/files/cover-art/", fileQuery(filePath, params))
+}
+
+// GetCoverArtImageInfo returns the format and dimensions of a file's cover
+// art, decoded from the image header fetched with a Range request. Servers
+// that ignore the Range, or headers too long to decode, cost a full fetch.
+func (c *Client) GetCoverArtImageInfo(ctx context.Context, filePath string) (ImageInfo, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.coverArtURL(filePath), nil, "")
+	if err != nil {
+		return ImageInfo{}, err
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=0-%d", coverArtHeaderBytes-1))
+	resp, err := c.sendRetrying(req)
+	if err != nil {
+		return ImageInfo{}, err
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp); err != nil {
+		return ImageInfo{}, err
+	}
+
+	if resp.StatusCode != http.StatusPartialContent {
+		return decodeImageInfo(resp.Body)
+	}
+	head, err := io.ReadAll(io.LimitReader(resp.Body, coverArtHeaderBytes))
+	if err != nil {
+		return ImageInfo{}, err
+	}
+	if info, err := decodeImageInfo(bytes.NewReader(head)); err == nil {
+		return info, nil
+	}
+	data, _, err := c.GetCoverArt(ctx, filePath)
+	if err != nil {
+		return ImageInfo{}, err
+	}
+	return decodeImageInfo(bytes.NewReader(data))
+}
+
+func decodeImageInfo(r io.Reader) (ImageInfo, error) {
+	cfg, format, err := image.DecodeConfig(r)
+	if err != nil {
+		return ImageInfo{}, err
+	}
+	return ImageInfo{Format: format, Width: cfg.Width, Height: cfg.Height}, nil
+}
+
+// GetCoverArtInfo returns the content type and size of a file's cover art
+// with a HEAD request, without downloading the image. size is -1 when the
+// server doesn't report it.
+func (c *Client) GetCoverArtInfo(ctx context.Context, filePath string) (contentType string, size int64, err error) {
+	req, err := c.newRequest(ctx, http.MethodHead, c.coverArtURL(filePath), nil, "")
+	if err != nil {
+		return "", 0, err
+	}
+	resp, err := c.sendRetrying(req)
+	if err != nil {
+		return "", 0, err
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp); err != nil {
+		return "", 0, err
+	}
+	return resp.Header.Get("Content-Type"), resp.ContentLength, nil
+}
+
+// imageContentType is the response's Content-Type for image bytes, sniffed
+// from data when the server sends none or only a generic binary type.
+func imageContentType(header string, data []byte) string {
+	if header == "" || strings.HasPrefix(header, "application/octet-stream") {
+		return http.DetectContentType(data)
+	}
+	return header
+}
+
+// CoverArtURL returns the song's ImageURL as an absolute URL. Absolute
+// values are returned unchanged; relative ones are resolved against the
+// client's BaseURL. It returns "" when the song has no image.
+func (s Song) CoverArtURL(c *Client) string {
+	raw := strings.TrimSpace(s.ImageURL)
+	if raw == "" {
+		return ""
+	}
+	ref, err := url.Parse(raw)
+	if err != nil || ref.IsAbs() {
+		return raw
+	}
+	base, err := c.baseURL()
+	if err != nil {
+		return raw
+	}
+	dir := *base
+	if !strings.HasSuffix(dir.Path, "/") {
+		dir.Path += "/"
+	}
+	return dir.ResolveReference(ref).String()
+}
@@ -0,0 +1,58 @@
+package juicewrld
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+func TestImageContentType(t *testing.T) {
+	var img bytes.Buffer
+	png.Encode(&img, image.NewGray(image.Rect(0, 0, 2, 3)))
+	tests := []struct {
+		header, want string
+	}{
+		{"", "image/png"},
+		{"application/octet-stream", "image/png"},
+		{"image/jpeg", "image/jpeg"},
+	}
+	for _, tt := range tests {
+		if got := imageContentType(tt.header, img.Bytes()); got != tt.want {
+			t.Errorf("imageContentType(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestCoverArtProbes(t *testing.T) {
+	var img bytes.Buffer
+	png.Encode(&img, image.NewGray(image.Rect(0, 0, 2, 3)))
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"/juicewrld/files/cover-art/": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Header().Set("Content-Length", strconv.Itoa(img.Len()))
+			if r.Method == http.MethodHead {
+				return
+			}
+			w.Write(img.Bytes())
+		},
+	})
+	ctx := context.Background()
+	c := api.client()
+
+	data, contentType, err := c.GetCoverArt(ctx, "song.mp3")
+	if err != nil || contentType != "image/png" || !bytes.Equal(data, img.Bytes()) {
+		t.Errorf("GetCoverArt = %d bytes of %q, %v; want the png", len(data), contentType, err)
+	}
+	contentType, size, err := c.GetCoverArtInfo(ctx, "song.mp3")
+	if err != nil || contentType != "application/octet-stream" || size != int64(img.Len()) {
+		t.Errorf("GetCoverArtInfo = %q, %d, %v", contentType, size, err)
+	}
+	info, err := c.GetCoverArtImageInfo(ctx, "song.mp3")
+	if err != nil || info != (ImageInfo{Format: "png", Width: 2, Height: 3}) {
+		t.Errorf("GetCoverArtImageInfo = %+v, %v", info, err)
+	}
+}
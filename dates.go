@@ -0,0 +1,63 @@
+package juicewrld
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// looseDayFormats are the looseDateFormats that carry a day of the month.
+var looseDayFormats = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+	"2006/01/02",
+	"01/02/2006",
+	"1/2/2006",
+	"01-02-2006",
+	"January 2, 2006",
+	"Jan 2, 2006",
+	"January 2 2006",
+	"Jan 2 2006",
+	"2 January 2006",
+	"2 Jan 2006",
+}
+
+var looseDateFormats = append(looseDayFormats,
+	"January 2006",
+	"Jan 2006",
+	"2006-01",
+	"2006",
+)
+
+func parseLooseDate(s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, false
+	}
+	for _, f := range looseDateFormats {
+		if t, err := time.Parse(f, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// parseLooseDay is parseLooseDate restricted to dates with a known day.
+func parseLooseDay(s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	for _, f := range looseDayFormats {
+		if t, err := time.Parse(f, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func (s Song) ReleaseDateParsed() (time.Time, error) {
+	t, ok := parseLooseDate(s.ReleaseDate)
+	if !ok {
+		return time.Time{}, fmt.Errorf("song %d has unparseable release date %q", s.ID, s.ReleaseDate)
+	}
+	return t, nil
+}
@@ -0,0 +1,182 @@
+package juicewrld
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SongHints narrows down which of several same-named songs FindSong should
+// return. Zero-valued fields are ignored.
+type SongHints struct {
+	Era          string
+	Category     string
+	Year         int
+	Producer     string
+	LengthApprox time.Duration
+}
+
+// HintWeights sets how much each kind of evidence counts when scoring
+// candidates. Length scores fall off linearly, reaching zero once the
+// difference from SongHints.LengthApprox reaches LengthTolerance.
+type HintWeights struct {
+	ExactName       float64
+	Era             float64
+	Category        float64
+	Year            float64
+	Producer        float64
+	Length          float64
+	LengthTolerance time.Duration
+}
+
+var DefaultHintWeights = HintWeights{
+	ExactName:       3,
+	Era:             2,
+	Category:        1,
+	Year:            1.5,
+	Producer:        1.5,
+	Length:          2,
+	LengthTolerance: 20 * time.Second,
+}
+
+// SongCandidate is a search result together with its disambiguation score.
+type SongCandidate struct {
+	Song  Song
+	Score float64
+}
+
+// Disambiguator resolves a song name to one song using SongHints. A match
+// is only returned when its score beats the runner-up by at least Margin.
+type Disambiguator struct {
+	Weights HintWeights
+	Margin  float64
+	// Limit caps how many search results are considered.
+	Limit int
+
+	client *Client
+}
+
+func NewDisambiguator(c *Client) *Disambiguator {
+	return &Disambiguator{
+		Weights: DefaultHintWeights,
+		Margin:  1,
+		Limit:   50,
+		client:  c,
+	}
+}
+
+// FindSong is shorthand for NewDisambiguator(c).FindSong.
+func (c *Client) FindSong(ctx context.Context, name string, hints SongHints) (Song, error) {
+	return NewDisambiguator(c).FindSong(ctx, name, hints)
+}
+
+// GetSongOrSearch fetches the song by ID and, if the ID is unknown, falls
+// back to searching for name and returning the best-ranked result. A
+// *NotFoundError is returned only when both lookups come up empty.
+func (c *Client) GetSongOrSearch(ctx context.Context, id int, name string) (Song, error) {
+	song, err := c.GetSong(ctx, id)
+	var notFound *NotFoundError
+	if err == nil || !errors.As(err, &notFound) || NormalizeSearchQuery(name) == "" {
+		return song, err
+	}
+	res, serr := c.SearchSongs(ctx, name, nil, nil, nil, defaultSearchLimit, 0)
+	if serr != nil {
+		return Song{}, serr
+	}
+	ranked := NewDisambiguator(c).Rank(name, res.Songs, SongHints{})
+	if len(ranked) == 0 {
+		return Song{}, err
+	}
+	return ranked[0].Song, nil
+}
+
+// FindSong searches for name and returns the best-scoring candidate. It
+// returns a *NotFoundError when the search finds nothing and an
+// *AmbiguousMatchError when no candidate clearly wins.
+func (d *Disambiguator) FindSong(ctx context.Context, name string, hints SongHints) (Song, error) {
+	res, err := d.client.SearchSongs(ctx, name, nil, nil, nil, d.Limit, 0)
+	if err != nil {
+		return Song{}, err
+	}
+	ranked := d.Rank(name, res.Songs, hints)
+	if len(ranked) == 0 {
+		return Song{}, &NotFoundError{APIError{StatusCode: 404, Message: "no song matches " + name}}
+	}
+	if len(ranked) > 1 && ranked[0].Score-ranked[1].Score < d.Margin {
+		n := 1
+		for n < len(ranked) && ranked[0].Score-ranked[n].Score < d.Margin {
+			n++
+		}
+		return Song{}, &AmbiguousMatchError{Name: name, Candidates: ranked[:n]}
+	}
+	return ranked[0].Song, nil
+}
+
+// Rank scores songs against name and hints, best first. Ties keep the
+// order of songs.
+func (d *Disambiguator) Rank(name string, songs []Song, hints SongHints) []SongCandidate {
+	out := make([]SongCandidate, len(songs))
+	for i, s := range songs {
+		out[i] = SongCandidate{Song: s, Score: d.score(foldString(strings.TrimSpace(name)), s, hints)}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Score > out[j].Score })
+	return out
+}
+
+func (d *Disambiguator) score(name string, s Song, h SongHints) float64 {
+	w := d.Weights
+	var score float64
+	if name != "" && nameMatches(name, s) {
+		score += w.ExactName
+	}
+	if h.Era != "" && foldString(s.Era.Name) == foldString(h.Era) {
+		score += w.Era
+	}
+	if h.Category != "" && foldString(s.Category) == foldString(h.Category) {
+		score += w.Category
+	}
+	if h.Year != 0 && songYear(s) == h.Year {
+		score += w.Year
+	}
+	if h.Producer != "" && containsFolded(s.Producers, foldString(h.Producer)) {
+		score += w.Producer
+	}
+	if h.LengthApprox > 0 && w.LengthTolerance > 0 {
+		if length, err := s.ParsedDuration(); err == nil {
+			diff := length - h.LengthApprox
+			if diff < 0 {
+				diff = -diff
+			}
+			if diff < w.LengthTolerance {
+				score += w.Length * (1 - float64(diff)/float64(w.LengthTolerance))
+			}
+		}
+	}
+	return score
+}
+
+func nameMatches(folded string, s Song) bool {
+	if foldString(s.Name) == folded {
+		return true
+	}
+	for _, t := range s.TrackTitles {
+		if foldString(strings.TrimSpace(t)) == folded {
+			return true
+		}
+	}
+	return false
+}
+
+// songYear returns the release year, falling back to the start of the
+// song's era for unreleased songs.
+func songYear(s Song) int {
+	if t, err := s.ReleaseDateParsed(); err == nil {
+		return t.Year()
+	}
+	if y, ok := s.Era.StartYear(); ok {
+		return y
+	}
+	return 0
+}
@@ -0,0 +1,134 @@
+package juicewrld
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// duplicateSongs are the search results for "Blood On My Jeans": the
+// released track, an earlier leaked version filed under another era, a
+// session take listed under its track title, and an unrelated song the
+// search matched loosely.
+var duplicateSongs = []map[string]interface{}{
+	{
+		"id": 101, "name": "Blood On My Jeans", "category": "Released",
+		"era":          map[string]interface{}{"id": 9, "name": "LND", "time_frame": "2020"},
+		"release_date": "2020-07-10", "length": "3:16", "producers": "Nick Mira, Dre Moon",
+	},
+	{
+		"id": 102, "name": "Blood On My Jeans", "category": "Unreleased",
+		"era":    map[string]interface{}{"id": 6, "name": "DRFL", "time_frame": "2018 - 2019"},
+		"length": "3:02", "producers": "Purps", "leak_type": "Full Leak",
+	},
+	{
+		"id": 103, "name": "Jeans (Session Take)", "category": "Unreleased", "track_titles": []string{"Blood On My Jeans"},
+		"era":    map[string]interface{}{"id": 6, "name": "DRFL", "time_frame": "2018 - 2019"},
+		"length": "5:41", "producers": "Purps", "leak_type": "Session",
+	},
+	{
+		"id": 104, "name": "Righteous", "category": "Released",
+		"era":    map[string]interface{}{"id": 9, "name": "LND", "time_frame": "2020"},
+		"length": "4:02", "producers": "Nick Mira",
+	},
+}
+
+func newDuplicateSongsAPI(t *testing.T) *fakeAPI {
+	t.Helper()
+	return newFakeAPI(t, map[string]http.HandlerFunc{
+		"/juicewrld/songs/": jsonHandler(map[string]interface{}{"count": len(duplicateSongs), "results": duplicateSongs}),
+	})
+}
+
+func TestFindSong(t *testing.T) {
+	tests := []struct {
+		name      string
+		hints     SongHints
+		weights   func(*HintWeights)
+		want      int
+		ambiguous []int
+	}{
+		{name: "no hints", ambiguous: []int{101, 102, 103}},
+		{name: "era", hints: SongHints{Era: "lnd"}, want: 101},
+		{name: "release year", hints: SongHints{Year: 2020}, want: 101},
+		{name: "era start year", hints: SongHints{Year: 2018}, ambiguous: []int{102, 103}},
+		{name: "producer", hints: SongHints{Producer: "dre moon"}, want: 101},
+		{name: "category", hints: SongHints{Category: "UNRELEASED"}, ambiguous: []int{102, 103}},
+		{name: "close length", hints: SongHints{LengthApprox: 3*time.Minute + 2*time.Second}, want: 102},
+		{name: "length out of tolerance", hints: SongHints{LengthApprox: 10 * time.Minute}, ambiguous: []int{101, 102, 103}},
+		{name: "era and length", hints: SongHints{Era: "DRFL", LengthApprox: 5*time.Minute + 40*time.Second}, want: 103},
+		{name: "conflicting hints", hints: SongHints{Era: "LND", LengthApprox: 3*time.Minute + 2*time.Second}, ambiguous: []int{101, 102}},
+		{
+			name: "era weight zeroed", hints: SongHints{Era: "LND"},
+			weights:   func(w *HintWeights) { w.Era = 0 },
+			ambiguous: []int{101, 102, 103},
+		},
+		{
+			name: "length weighted up", hints: SongHints{Era: "LND", LengthApprox: 3*time.Minute + 2*time.Second},
+			weights: func(w *HintWeights) { w.Length = 6 },
+			want:    102,
+		},
+	}
+	api := newDuplicateSongsAPI(t)
+	c := api.client()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDisambiguator(c)
+			if tt.weights != nil {
+				tt.weights(&d.Weights)
+			}
+			song, err := d.FindSong(context.Background(), "Blood On My Jeans", tt.hints)
+			if tt.ambiguous != nil {
+				var amb *AmbiguousMatchError
+				if !errors.As(err, &amb) {
+					t.Fatalf("FindSong = %d, %v; want an AmbiguousMatchError", song.ID, err)
+				}
+				var got []int
+				for _, cand := range amb.Candidates {
+					got = append(got, cand.Song.ID)
+				}
+				if !reflect.DeepEqual(got, tt.ambiguous) {
+					t.Errorf("candidates = %v, want %v", got, tt.ambiguous)
+				}
+				return
+			}
+			if err != nil || song.ID != tt.want {
+				t.Errorf("FindSong = %d, %v; want %d", song.ID, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindSongNotFound(t *testing.T) {
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"/juicewrld/songs/": jsonHandler(map[string]interface{}{"count": 0, "results": []interface{}{}}),
+	})
+	_, err := api.client().FindSong(context.Background(), "Blood On My Jeans", SongHints{Era: "LND"})
+	var notFound *NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Errorf("err = %v, want a NotFoundError", err)
+	}
+}
+
+func TestAmbiguousMatchErrorListsAttributes(t *testing.T) {
+	api := newDuplicateSongsAPI(t)
+	_, err := api.client().FindSong(context.Background(), "Blood On My Jeans", SongHints{})
+	want := `3 songs match "Blood On My Jeans":` +
+		` [#101 era="LND" length="3:16" leak=""]` +
+		` [#102 era="DRFL" length="3:02" leak="Full Leak"]` +
+		` [#103 era="DRFL" length="5:41" leak="Session"]`
+	if err == nil || err.Error() != want {
+		t.Errorf("err = %v\nwant %s", err, want)
+	}
+}
+
+func TestGetSongOrSearchFallsBackToBestMatch(t *testing.T) {
+	api := newDuplicateSongsAPI(t)
+	song, err := api.client().GetSongOrSearch(context.Background(), 999, "Righteous")
+	if err != nil || song.ID != 104 {
+		t.Errorf("GetSongOrSearch = %d, %v; want 104", song.ID, err)
+	}
+}
@@ -0,0 +1,356 @@
+package juicewrld
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	verifySampleSize = 16 << 10
+	verifyTailSize   = 64 << 10
+)
+
+var errRangeUnsupported = errors.New("server does not support range requests")
+
+func WithVerifySamples(samples int) Option {
+	return func(c *Client) {
+		c.verifySamples = samples
+	}
+}
+
+func WithRandSeed(seed int64) Option {
+	return func(c *Client) {
+		c.rng = rand.New(rand.NewSource(seed))
+	}
+}
+
+func (c *Client) randInt63n(n int64) int64 {
+	c.rngMu.Lock()
+	defer c.rngMu.Unlock()
+	if c.rng == nil {
+		return rand.Int63n(n)
+	}
+	return c.rng.Int63n(n)
+}
+
+// DownloadURL returns the URL the file is downloaded and streamed from.
+// params are added to the query next to path, for servers that accept
+// transformation parameters such as format or quality; path itself cannot
+// be overridden.
+func (c *Client) DownloadURL(filePath string, params ...url.Values) string {
+	return c.downloadURL(filePath, params...)
+}
+
+func (c *Client) downloadURL(filePath string, params ...url.Values) string {
+	return c.endpointURL("/juicewrld/files/download/", fileQuery(filePath, params))
+}
+
+func fileQuery(filePath string, params []url.Values) url.Values {
+	q := url.Values{}
+	for _, p := range params {
+		for k, vs := range p {
+			q[k] = append(q[k], vs...)
+		}
+	}
+	q.Set("path", filePath)
+	return q
+}
+
+func (c *Client) fetchRange(ctx context.Context, filePath string, start, end int64) ([]byte, int64, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.downloadURL(filePath), nil, "")
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", start, end))
+	resp, err := c.sendRetrying(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp); err != nil {
+		return nil, 0, err
+	}
+	if err := c.checkDownloadType(resp, filePath); err != nil {
+		return nil, 0, err
+	}
+	if resp.StatusCode != http.StatusPartialContent {
+		return nil, 0, errRangeUnsupported
+	}
+	data, err := io.ReadAll(io.LimitReader(c.throttle(ctx, resp.Body), end-start+1))
+	if err != nil {
+		return nil, 0, err
+	}
+	return data, contentRangeTotal(resp.Header.Get("Content-Range")), nil
+}
+
+func contentRangeTotal(v string) int64 {
+	i := strings.LastIndex(v, "/")
+	if i < 0 {
+		return -1
+	}
+	total, err := strconv.ParseInt(strings.TrimSpace(v[i+1:]), 10, 64)
+	if err != nil {
+		return -1
+	}
+	return total
+}
+
+func (c *Client) VerifyDownload(ctx context.Context, remotePath, localPath string, samples int) error {
+	f, err := os.Open(localPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	st, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	size := st.Size()
+	if size == 0 {
+		info, err := c.GetFileInfo(ctx, remotePath)
+		if err != nil {
+			return err
+		}
+		if info.Size > 0 {
+			return &CorruptDownloadError{Path: localPath, Offset: 0}
+		}
+		return nil
+	}
+
+	for _, off := range c.sampleOffsets(size, samples) {
+		n := min(int64(verifySampleSize), size-off)
+		if off == max(size-verifyTailSize, 0) {
+			n = size - off
+		}
+		remote, total, err := c.fetchRange(ctx, remotePath, off, off+n-1)
+		if err != nil {
+			return err
+		}
+		if total >= 0 && total != size {
+			return &CorruptDownloadError{Path: localPath, Offset: min(total, size)}
+		}
+		local := make([]byte, n)
+		if _, err := f.ReadAt(local, off); err != nil && err != io.EOF {
+			return err
+		}
+		for i := int64(0); i < n; i++ {
+			if i >= int64(len(remote)) || remote[i] != local[i] {
+				return &CorruptDownloadError{Path: localPath, Offset: off + i}
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Client) sampleOffsets(size int64, samples int) []int64 {
+	seen := map[int64]bool{}
+	var out []int64
+	add := func(off int64) {
+		if !seen[off] {
+			seen[off] = true
+			out = append(out, off)
+		}
+	}
+	for i := 0; i < samples && size > verifySampleSize; i++ {
+		add(c.randInt63n(size - verifySampleSize + 1))
+	}
+	if size <= verifySampleSize && samples > 0 {
+		add(0)
+	}
+	add(max(size-verifyTailSize, 0))
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+type DownloadTask struct {
+	RemotePath string
+	LocalPath  string
+}
+
+type DownloadResult struct {
+	Task DownloadTask
+	Err  error
+}
+
+func (c *Client) DownloadFiles(ctx context.Context, tasks []DownloadTask, concurrency int) ([]DownloadResult, error) {
+	ctx = withDefaultRetryBudget(ctx)
+	results := make([]DownloadResult, len(tasks))
+	for i, t := range tasks {
+		results[i].Task = t
+	}
+	err := runConcurrent(ctx, len(tasks), concurrency, func(ctx context.Context, i int) error {
+		_, err := c.DownloadFileTo(ctx, tasks[i].RemotePath, tasks[i].LocalPath)
+		results[i].Err = err
+		return nil
+	})
+	if err != nil {
+		return results, err
+	}
+	if err := ctx.Err(); err != nil {
+		return results, err
+	}
+	var errs []error
+	for _, r := range results {
+		if r.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", r.Task.RemotePath, r.Err))
+		}
+	}
+	return results, errors.Join(errs...)
+}
+
+const (
+	// parallelMinSize is the smallest file DownloadFileParallel splits;
+	// below it the extra connections cost more than they save.
+	parallelMinSize = 8 << 20
+	// parallelRounds bounds how often failed parts are retried.
+	parallelRounds = 3
+	// maxParallelParts caps the concurrent range requests of one download.
+	maxParallelParts = 16
+)
+
+type byteRange struct {
+	start, end int64
+}
+
+// DownloadFileParallel downloads remotePath to savePath over parts
+// concurrent range requests, each writing at its own offset of a
+// preallocated file. parts is capped at 16. Files under 8 MiB, a parts
+// value below 2 and servers without range support fall back to
+// DownloadFileTo, which streams the file to disk. Parts that fail are
+// retried on their own, up to three rounds. Reads share the bandwidth
+// limit, and the finished file must match the size the server reported
+// before it is moved into place.
+func (c *Client) DownloadFileParallel(ctx context.Context, remotePath, savePath string, parts int) error {
+	ctx = withDefaultRetryBudget(ctx)
+	parts = min(parts, maxParallelParts)
+	_, total, err := c.fetchRange(ctx, remotePath, 0, 0)
+	if errors.Is(err, errRangeUnsupported) || (err == nil && (total < parallelMinSize || parts < 2)) {
+		_, err := c.DownloadFileTo(ctx, remotePath, savePath)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	tmp := savePath + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	err = c.downloadParts(ctx, remotePath, f, splitRanges(total, parts))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = checkFileSize(tmp, total)
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, savePath); err != nil {
+		return err
+	}
+	if c.verifySamples > 0 {
+		return c.VerifyDownload(ctx, remotePath, savePath, c.verifySamples)
+	}
+	return nil
+}
+
+func splitRanges(total int64, parts int) []byteRange {
+	size := (total + int64(parts) - 1) / int64(parts)
+	var out []byteRange
+	for start := int64(0); start < total; start += size {
+		out = append(out, byteRange{start: start, end: min(start+size, total) - 1})
+	}
+	return out
+}
+
+func (c *Client) downloadParts(ctx context.Context, remotePath string, f *os.File, pending []byteRange) error {
+	if err := f.Truncate(pending[len(pending)-1].end + 1); err != nil {
+		return err
+	}
+	var errs []error
+	for round := 0; round < parallelRounds && len(pending) > 0; round++ {
+		// Each part fetched again is a retry charged to the budget.
+		if round > 0 {
+			for range pending {
+				if !spendRetry(ctx) {
+					return &BudgetExhaustedError{Err: errors.Join(errs...)}
+				}
+			}
+		}
+		errs = make([]error, len(pending))
+		err := runConcurrent(ctx, len(pending), len(pending), func(ctx context.Context, i int) error {
+			errs[i] = c.downloadPart(ctx, remotePath, f, pending[i])
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		var failed []byteRange
+		var failedErrs []error
+		for i, e := range errs {
+			if e != nil {
+				failed = append(failed, pending[i])
+				failedErrs = append(failedErrs, e)
+			}
+		}
+		pending, errs = failed, failedErrs
+	}
+	return errors.Join(errs...)
+}
+
+func (c *Client) downloadPart(ctx context.Context, remotePath string, f *os.File, r byteRange) error {
+	req, err := c.newRequest(ctx, http.MethodGet, c.downloadURL(remotePath), nil, "")
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", r.start, r.end))
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp); err != nil {
+		return err
+	}
+	if err := c.checkDownloadType(resp, remotePath); err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusPartialContent {
+		return errRangeUnsupported
+	}
+	want := r.end - r.start + 1
+	n, err := io.Copy(io.NewOffsetWriter(f, r.start), io.LimitReader(c.throttle(ctx, resp.Body), want))
+	if err != nil {
+		return err
+	}
+	if n != want {
+		return fmt.Errorf("range %d-%d: got %d of %d bytes", r.start, r.end, n, want)
+	}
+	return nil
+}
+
+func checkFileSize(path string, want int64) error {
+	st, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if st.Size() != want {
+		return &CorruptDownloadError{Path: path, Offset: min(st.Size(), want)}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package juicewrld
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDownloadFileParallelCapsParts(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789abcdef"), (parallelMinSize+1<<20)/16)
+	var ranged atomic.Int32
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"/juicewrld/files/download/": func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Range") != "" {
+				ranged.Add(1)
+			}
+			http.ServeContent(w, r, "big.mp3", time.Time{}, bytes.NewReader(data))
+		},
+	})
+	dst := filepath.Join(t.TempDir(), "big.mp3")
+
+	if err := api.client().DownloadFileParallel(context.Background(), "big.mp3", dst, 1000); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := os.ReadFile(dst); err != nil || !bytes.Equal(got, data) {
+		t.Errorf("downloaded file differs from the served one (err %v)", err)
+	}
+	if n := ranged.Load(); n != 1+maxParallelParts {
+		t.Errorf("%d range requests, want a probe and %d parts", n, maxParallelParts)
+	}
+}
+
+func TestDownloadFileParallelStreamsWithoutRangeSupport(t *testing.T) {
+	data := bytes.Repeat([]byte("juice"), 1<<16)
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		// Range headers are ignored: every request gets the whole file.
+		"/juicewrld/files/download/": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "audio/mpeg")
+			w.Write(data)
+		},
+	})
+	dst := filepath.Join(t.TempDir(), "song.mp3")
+
+	if err := api.client().DownloadFileParallel(context.Background(), "song.mp3", dst, 4); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := os.ReadFile(dst); err != nil || !bytes.Equal(got, data) {
+		t.Errorf("downloaded file differs from the served one (err %v)", err)
+	}
+	if _, err := os.Stat(dst + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("temporary file left behind: %v", err)
+	}
+}
+
+func TestVerifyDownload(t *testing.T) {
+	remote := make([]byte, 1<<20)
+	for i := range remote {
+		remote[i] = byte(i * 7)
+	}
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"/juicewrld/files/download/": func(w http.ResponseWriter, r *http.Request) {
+			http.ServeContent(w, r, "song.mp3", time.Time{}, bytes.NewReader(remote))
+		},
+		"/juicewrld/files/info/": func(w http.ResponseWriter, r *http.Request) {
+			size := len(remote)
+			if r.URL.Query().Get("path") == "empty.mp3" {
+				size = 0
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{"name": "song.mp3", "type": "file", "size": size})
+		},
+	})
+	corrupt := func(at ...int) []byte {
+		local := bytes.Clone(remote)
+		for _, i := range at {
+			local[i] ^= 0xff
+		}
+		return local
+	}
+	// middle is corrupted in a block the tail sample does not reach, so
+	// only the seeded random samples can find it.
+	const middleStart, middleEnd = 200 << 10, 900 << 10
+	middle := bytes.Clone(remote)
+	for i := middleStart; i < middleEnd; i++ {
+		middle[i] ^= 0xff
+	}
+	offsets := New(api.URL, WithRandSeed(7)).sampleOffsets(int64(len(remote)), 8)
+	wantMiddle := int64(-1)
+	for _, off := range offsets {
+		if end := off + verifySampleSize; end > middleStart && off < middleEnd {
+			wantMiddle = max(off, middleStart)
+			break
+		}
+	}
+	if wantMiddle < 0 {
+		t.Fatalf("seed 7 samples %v miss the corrupted block; pick another seed", offsets)
+	}
+
+	tests := []struct {
+		name    string
+		remote  string
+		local   []byte
+		samples int
+		offset  int64 // -1 for a file that verifies
+	}{
+		{"intact", "song.mp3", remote, 8, -1},
+		{"truncated", "song.mp3", remote[:600<<10], 8, 600 << 10},
+		{"tail byte", "song.mp3", corrupt(len(remote) - 10), 0, int64(len(remote) - 10)},
+		{"first tail byte", "song.mp3", corrupt(len(remote) - verifyTailSize), 0, int64(len(remote) - verifyTailSize)},
+		{"sampled block", "song.mp3", middle, 8, wantMiddle},
+		{"unsampled block", "song.mp3", middle, 0, -1},
+		{"empty local file", "song.mp3", nil, 8, 0},
+		{"empty remote file", "empty.mp3", nil, 8, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			local := filepath.Join(t.TempDir(), "song.mp3")
+			if err := os.WriteFile(local, tt.local, 0o644); err != nil {
+				t.Fatal(err)
+			}
+			err := api.client(WithRandSeed(7)).VerifyDownload(context.Background(), tt.remote, local, tt.samples)
+			var bad *CorruptDownloadError
+			switch {
+			case tt.offset < 0 && err != nil:
+				t.Errorf("VerifyDownload = %v, want nil", err)
+			case tt.offset >= 0 && !errors.As(err, &bad):
+				t.Errorf("VerifyDownload = %v, want a CorruptDownloadError", err)
+			case tt.offset >= 0 && (bad.Offset != tt.offset || bad.Path != local):
+				t.Errorf("CorruptDownloadError = %+v, want offset %d in %s", bad, tt.offset, local)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package juicewrld
+
+import (
+	"context"
+	"hash/fnv"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	apostrophes     = strings.NewReplacer("'", "", "’", "")
+	bracketed       = regexp.MustCompile(`\([^)]*\)|\[[^\]]*\]|\{[^}]*\}`)
+	nonAlphanumeric = regexp.MustCompile(`[^\p{L}\p{N}]+`)
+)
+
+// NormalizeSongTitle is the default title key for duplicate detection:
+// accents and case are folded, bracketed qualifiers and credits such as
+// "[V2]", "(snippet)" or "(prod. Nick Mira)" are removed and punctuation is
+// dropped, so "Song Name", "Song Name [V2]" and "song name (snippet)" all
+// share the key "song name".
+func NormalizeSongTitle(title string) string {
+	title = bracketed.ReplaceAllString(apostrophes.Replace(foldString(title)), " ")
+	return strings.Join(strings.Fields(nonAlphanumeric.ReplaceAllString(title, " ")), " ")
+}
+
+// DuplicateFinder groups songs that are likely the same track: songs whose
+// titles share a key, from the same era, with the same length. Find works
+// on songs already in memory; FindInCatalog streams the whole catalog.
+type DuplicateFinder struct {
+	// Normalize maps a title to its comparison key; nil uses
+	// NormalizeSongTitle. Songs with an empty key are never grouped.
+	Normalize func(title string) string
+	// IgnoreEra matches songs across eras.
+	IgnoreEra bool
+	// IgnoreLength matches songs regardless of their length.
+	IgnoreLength bool
+	// LengthTolerance lets lengths within a group differ by up to this
+	// much from their neighbour; zero requires equal lengths (to the
+	// second). Songs of unknown length only group with each other.
+	LengthTolerance time.Duration
+}
+
+func NewDuplicateFinder() *DuplicateFinder {
+	return &DuplicateFinder{Normalize: NormalizeSongTitle}
+}
+
+// FindDuplicateSongs is shorthand for NewDuplicateFinder().Find.
+func FindDuplicateSongs(songs []Song) [][]Song {
+	return NewDuplicateFinder().Find(songs)
+}
+
+// SongRef is the compact record FindInCatalog keeps per song.
+type SongRef struct {
+	ID   int
+	Name string
+	// Era is the song's era ID.
+	Era int
+	// Length is zero when the song's length is unknown.
+	Length time.Duration
+	// Key is the normalized title the song was grouped under.
+	Key string
+}
+
+// Find returns groups of two or more likely duplicates, largest first and
+// then in order of their first member. Members keep their input order.
+func (f *DuplicateFinder) Find(songs []Song) [][]Song {
+	var refs []SongRef
+	var index []int
+	for i, s := range songs {
+		if ref, ok := f.ref(s); ok {
+			refs = append(refs, ref)
+			index = append(index, i)
+		}
+	}
+	var out [][]Song
+	for _, g := range f.group(refs) {
+		group := make([]Song, len(g))
+		for i, pos := range g {
+			group[i] = songs[index[pos]]
+		}
+		out = append(out, group)
+	}
+	return out
+}
+
+// dupCounterSize is the number of one-byte counters FindInCatalog's first
+// pass uses, which fixes that pass's memory at 1 MiB.
+const dupCounterSize = 1 << 20
+
+// FindInCatalog finds duplicates across the catalog, or the songs matching
+// filter, grouped and ordered like Find.
+//
+// Memory is bounded independently of the catalog size: the first pass
+// counts keys in a fixed 1 MiB table of hashed counters, and the second
+// keeps a SongRef (about 100 bytes plus the title) only for songs whose key
+// was seen more than once, so what is held is essentially the result. One
+// page of full records is in memory at a time and no pairwise comparison
+// is made. The price is two passes over the catalog; songs added between
+// them can be missed.
+func (f *DuplicateFinder) FindInCatalog(ctx context.Context, c *Client, filter *SongFilter) ([][]SongRef, error) {
+	ctx = withDefaultRetryBudget(ctx)
+	counts := make([]uint8, dupCounterSize)
+	err := f.scan(ctx, c, filter, func(ref SongRef) {
+		if i := f.bucket(ref); counts[i] < 2 {
+			counts[i]++
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	var refs []SongRef
+	err = f.scan(ctx, c, filter, func(ref SongRef) {
+		if counts[f.bucket(ref)] > 1 {
+			refs = append(refs, ref)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	var out [][]SongRef
+	for _, g := range f.group(refs) {
+		group := make([]SongRef, len(g))
+		for i, pos := range g {
+			group[i] = refs[pos]
+		}
+		out = append(out, group)
+	}
+	return out, nil
+}
+
+func (f *DuplicateFinder) scan(ctx context.Context, c *Client, filter *SongFilter, fn func(SongRef)) error {
+	q := c.songFilterQuery(filter)
+	q.Del("page")
+	pages := NewPaginator[Song](c, "/juicewrld/songs/", q)
+	for {
+		songs, ok, err := pages.Next(ctx)
+		if err != nil || !ok {
+			return err
+		}
+		for _, s := range c.exclude(filter, songs) {
+			if ref, ok := f.ref(s); ok {
+				fn(ref)
+			}
+		}
+	}
+}
+
+func (f *DuplicateFinder) ref(s Song) (SongRef, bool) {
+	normalize := NormalizeSongTitle
+	if f.Normalize != nil {
+		normalize = f.Normalize
+	}
+	key := normalize(s.Name)
+	if key == "" {
+		return SongRef{}, false
+	}
+	d, _ := s.ParsedDuration()
+	return SongRef{ID: s.ID, Name: s.Name, Era: s.Era.ID, Length: d.Truncate(time.Second), Key: key}, true
+}
+
+// groupKey is what songs must share before lengths are compared.
+func (f *DuplicateFinder) groupKey(ref SongRef) SongRef {
+	k := SongRef{Key: ref.Key}
+	if !f.IgnoreEra {
+		k.Era = ref.Era
+	}
+	return k
+}
+
+func (f *DuplicateFinder) bucket(ref SongRef) int {
+	h := fnv.New32a()
+	k := f.groupKey(ref)
+	h.Write([]byte(k.Key))
+	h.Write([]byte{byte(k.Era), byte(k.Era >> 8), byte(k.Era >> 16), byte(k.Era >> 24)})
+	return int(h.Sum32() % dupCounterSize)
+}
+
+// group returns the positions in refs of each group of two or more,
+// ordered as Find documents.
+func (f *DuplicateFinder) group(refs []SongRef) [][]int {
+	buckets := map[SongRef][]int{}
+	var order []SongRef
+	for i, ref := range refs {
+		k := f.groupKey(ref)
+		if _, ok := buckets[k]; !ok {
+			order = append(order, k)
+		}
+		buckets[k] = append(buckets[k], i)
+	}
+	var out [][]int
+	for _, k := range order {
+		members := buckets[k]
+		if len(members) < 2 {
+			continue
+		}
+		if f.IgnoreLength {
+			out = append(out, members)
+			continue
+		}
+		out = append(out, splitByLength(refs, members, f.LengthTolerance)...)
+	}
+	for _, g := range out {
+		sort.Ints(g)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if len(out[i]) != len(out[j]) {
+			return len(out[i]) > len(out[j])
+		}
+		return out[i][0] < out[j][0]
+	})
+	return out
+}
+
+// splitByLength breaks members into runs of similar length, keeping only
+// runs of two or more.
+func splitByLength(refs []SongRef, members []int, tolerance time.Duration) [][]int {
+	sorted := append([]int(nil), members...)
+	sort.SliceStable(sorted, func(i, j int) bool { return refs[sorted[i]].Length < refs[sorted[j]].Length })
+	var out [][]int
+	start := 0
+	for i := 1; i <= len(sorted); i++ {
+		if i < len(sorted) {
+			prev, cur := refs[sorted[i-1]].Length, refs[sorted[i]].Length
+			if (prev == 0) == (cur == 0) && cur-prev <= tolerance {
+				continue
+			}
+		}
+		if i-start > 1 {
+			out = append(out, sorted[start:i])
+		}
+		start = i
+	}
+	return out
+}
@@ -0,0 +1,128 @@
+package juicewrld
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestNormalizeSongTitle(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"Lucid Dreams", "lucid dreams"},
+		{"  Lucid   Dreams [V2] ", "lucid dreams"},
+		{"Lucid Dreams (prod. Nick Mira)", "lucid dreams"},
+		{"Lucid Dreams [feat. Trippie Redd] (snippet)", "lucid dreams"},
+		{"Don’t Go", "dont go"},
+		{"Séance!", "seance"},
+		{"(snippet)", ""},
+	}
+	for _, tt := range tests {
+		if got := NormalizeSongTitle(tt.in); got != tt.want {
+			t.Errorf("NormalizeSongTitle(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func dupSong(id int, name string, era int, length string) Song {
+	return Song{ID: id, Name: name, Era: Era{ID: era}, Length: length}
+}
+
+func songIDs(groups [][]Song) [][]int {
+	var out [][]int
+	for _, g := range groups {
+		var ids []int
+		for _, s := range g {
+			ids = append(ids, s.ID)
+		}
+		out = append(out, ids)
+	}
+	return out
+}
+
+func TestDuplicateFinderFind(t *testing.T) {
+	songs := []Song{
+		dupSong(1, "Song A", 1, "3:00"),
+		dupSong(2, "Song B", 1, "2:00"),
+		dupSong(3, "Song B [V2]", 1, "2:00"),
+		dupSong(4, "song a (snippet)", 1, "3:00"),
+		dupSong(5, "Song A", 2, "3:00"),
+		dupSong(6, "Song B (prod. X)", 1, "2:00"),
+		dupSong(7, "Song A", 1, "3:04"),
+		dupSong(8, "Song C", 1, ""),
+		dupSong(9, "Song C", 1, ""),
+		dupSong(10, "(snippet)", 1, ""),
+		dupSong(11, "[V2]", 1, ""),
+	}
+	tests := []struct {
+		name string
+		f    DuplicateFinder
+		want [][]int
+	}{
+		{"defaults", DuplicateFinder{}, [][]int{{2, 3, 6}, {1, 4}, {8, 9}}},
+		{"tolerance", DuplicateFinder{LengthTolerance: 5 * time.Second}, [][]int{{1, 4, 7}, {2, 3, 6}, {8, 9}}},
+		{"ignore length", DuplicateFinder{IgnoreLength: true}, [][]int{{1, 4, 7}, {2, 3, 6}, {8, 9}}},
+		{"ignore era", DuplicateFinder{IgnoreEra: true}, [][]int{{1, 4, 5}, {2, 3, 6}, {8, 9}}},
+	}
+	for _, tt := range tests {
+		if got := songIDs(tt.f.Find(songs)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Find = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if got := songIDs(FindDuplicateSongs(songs)); !reflect.DeepEqual(got, tests[0].want) {
+		t.Errorf("FindDuplicateSongs = %v, want %v", got, tests[0].want)
+	}
+}
+
+func TestDuplicateFinderFindInCatalog(t *testing.T) {
+	songs := []Song{
+		dupSong(1, "Song A", 1, "3:00"),
+		dupSong(2, "Song B", 1, "2:00"),
+		dupSong(3, "Song B [V2]", 1, "2:00"),
+		dupSong(4, "song a (snippet)", 1, "3:02"),
+		dupSong(5, "Song D", 1, "1:00"),
+	}
+	var api *fakeAPI
+	api = newFakeAPI(t, map[string]http.HandlerFunc{
+		"/juicewrld/songs/": func(w http.ResponseWriter, r *http.Request) {
+			page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+			if page < 1 {
+				page = 1
+			}
+			start := (page - 1) * 2
+			end := start + 2
+			var next interface{}
+			if end < len(songs) {
+				next = api.URL + "/juicewrld/songs/?page=" + strconv.Itoa(page+1)
+			} else {
+				end = len(songs)
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{"count": len(songs), "next": next, "results": songs[start:end]})
+		},
+	})
+
+	f := &DuplicateFinder{LengthTolerance: 5 * time.Second}
+	groups, err := f.FindInCatalog(context.Background(), api.client(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got [][]int
+	for _, g := range groups {
+		var ids []int
+		for _, ref := range g {
+			ids = append(ids, ref.ID)
+		}
+		got = append(got, ids)
+	}
+	if want := songIDs(f.Find(songs)); !reflect.DeepEqual(got, want) {
+		t.Errorf("FindInCatalog = %v, Find = %v", got, want)
+	}
+	if len(groups) != 2 || groups[0][0].Key != "song a" || groups[0][1].Length != 182*time.Second {
+		t.Errorf("groups = %+v", groups)
+	}
+	if n := api.hitCount("/juicewrld/songs/"); n != 6 {
+		t.Errorf("songs requested %d times, want two passes of 3 pages", n)
+	}
+}
@@ -0,0 +1,182 @@
+package juicewrld
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+type EraWithSongs struct {
+	Era
+	Songs Songs `json:"songs"`
+}
+
+// UnmarshalJSON decodes the embedded era and the songs separately, since
+// Era's own UnmarshalJSON would otherwise swallow the songs field.
+func (e *EraWithSongs) UnmarshalJSON(data []byte) error {
+	var songs struct {
+		Songs Songs `json:"songs"`
+	}
+	if err := json.Unmarshal(data, &songs); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &e.Era); err != nil {
+		return err
+	}
+	e.Songs = songs.Songs
+	return nil
+}
+
+func (e EraWithSongs) CategoryBreakdown() map[string]int {
+	out := make(map[string]int)
+	for _, s := range e.Songs {
+		out[s.Category]++
+	}
+	return out
+}
+
+func (e EraWithSongs) TotalDuration() (time.Duration, error) {
+	return sumDurations(e.Songs)
+}
+
+// GetEraWithSongs returns the era with all of its songs. The songs
+// endpoint filters eras by name, so the era is fetched first and its songs
+// are then listed under that name.
+func (c *Client) GetEraWithSongs(ctx context.Context, eraID int) (EraWithSongs, error) {
+	ctx = withDefaultRetryBudget(ctx)
+	era, err := c.GetEra(ctx, eraID)
+	if err != nil {
+		return EraWithSongs{}, err
+	}
+	songs, err := c.collectSongs(ctx, url.Values{"era": {era.Name}})
+	if err != nil {
+		return EraWithSongs{}, err
+	}
+	return EraWithSongs{Era: era, Songs: songs}, nil
+}
+
+// GetAllEras returns every era. The eras endpoint is not paginated today,
+// so this is a single request like GetEras; if the server ever starts
+// returning a next link, GetAllEras follows it while GetEras keeps
+// returning only the first page.
+func (c *Client) GetAllEras(ctx context.Context) ([]Era, error) {
+	return NewPaginator[Era](c, "/juicewrld/eras/", nil).All(withDefaultRetryBudget(ctx))
+}
+
+// EraResolved reports whether the song's era carries details. Endpoints
+// that send only the era ID leave it unresolved until HydrateEras runs.
+func (s Song) EraResolved() bool {
+	return s.Era.ID == 0 || s.Era.Name != ""
+}
+
+// HydrateEras fills in the details of unresolved eras in songs, in place,
+// from the eras list (cached when WithCache is set). IDs missing from the
+// list are left as they are.
+func (c *Client) HydrateEras(ctx context.Context, songs []Song) error {
+	pending := false
+	for _, s := range songs {
+		if !s.EraResolved() {
+			pending = true
+			break
+		}
+	}
+	if !pending {
+		return nil
+	}
+	eras, err := c.GetEras(ctx)
+	if err != nil {
+		return err
+	}
+	byID := make(map[int]Era, len(eras))
+	for _, e := range eras {
+		byID[e.ID] = e
+	}
+	for i := range songs {
+		if songs[i].EraResolved() {
+			continue
+		}
+		if e, ok := byID[songs[i].Era.ID]; ok {
+			songs[i].Era = e
+		}
+	}
+	return nil
+}
+
+// StartYear returns the first four-digit year in TimeFrame, so "2015-2017"
+// gives 2015. ok is false when TimeFrame holds no year.
+func (e Era) StartYear() (year int, ok bool) {
+	digits := 0
+	for i, r := range e.TimeFrame {
+		if r >= '0' && r <= '9' {
+			digits++
+			if digits == 4 {
+				year, err := strconv.Atoi(e.TimeFrame[i-3 : i+1])
+				return year, err == nil
+			}
+			continue
+		}
+		digits = 0
+	}
+	return 0, false
+}
+
+// GetErasChronological returns GetEras sorted by StartYear, earliest first.
+// Eras whose TimeFrame has no year come last; ties keep ID order.
+func (c *Client) GetErasChronological(ctx context.Context) ([]Era, error) {
+	eras, err := c.GetEras(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sortErasChronologically(eras)
+	return eras, nil
+}
+
+func sortErasChronologically(eras []Era) {
+	sort.SliceStable(eras, func(i, j int) bool {
+		yi, oki := eras[i].StartYear()
+		yj, okj := eras[j].StartYear()
+		if oki != okj {
+			return oki
+		}
+		if yi != yj {
+			return yi < yj
+		}
+		return eras[i].ID < eras[j].ID
+	})
+}
+
+// SongCount returns how many songs belong to the era, read from the count
+// of a one-song page rather than by fetching them all. The count is the
+// server's, so it includes removed songs (see Song.IsRemoved). Listings
+// are filtered by era name, so an era known only by ID is looked up first.
+func (e Era) SongCount(ctx context.Context, c *Client) (int, error) {
+	return e.countSongs(ctx, c, "")
+}
+
+// ReleasedSongCount is SongCount restricted to the "released" category.
+func (e Era) ReleasedSongCount(ctx context.Context, c *Client) (int, error) {
+	return e.countSongs(ctx, c, "released")
+}
+
+// UnreleasedSongCount is SongCount restricted to the "unreleased" category.
+func (e Era) UnreleasedSongCount(ctx context.Context, c *Client) (int, error) {
+	return e.countSongs(ctx, c, "unreleased")
+}
+
+func (e Era) countSongs(ctx context.Context, c *Client, category string) (int, error) {
+	if e.Name == "" && e.ID != 0 {
+		full, err := c.GetEra(ctx, e.ID)
+		if err != nil {
+			return 0, err
+		}
+		e = full
+	}
+	page, err := c.ListSongs(ctx, &SongFilter{Era: e.Name, Category: category, PageSize: 1, IncludeRemoved: true})
+	if err != nil {
+		return 0, err
+	}
+	return page.Count, nil
+}
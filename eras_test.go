@@ -0,0 +1,107 @@
+package juicewrld
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// newEraFilterAPI serves era 3, "DRFL", and answers the songs listing only
+// when it is filtered by that era's name.
+func newEraFilterAPI(t *testing.T) *fakeAPI {
+	t.Helper()
+	return newFakeAPI(t, map[string]http.HandlerFunc{
+		"/juicewrld/eras/3/": jsonHandler(map[string]interface{}{"id": 3, "name": "DRFL"}),
+		"/juicewrld/songs/": func(w http.ResponseWriter, r *http.Request) {
+			if era := r.URL.Query().Get("era"); era != "DRFL" {
+				t.Errorf("songs requested with era=%q, want the era name", era)
+			}
+			if size := r.URL.Query().Get("page_size"); size != "" && size != "1" {
+				t.Errorf("songs requested with page_size=%s", size)
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{"count": 2, "results": []map[string]interface{}{
+				{"id": 1, "name": "Lucid Dreams", "era": map[string]interface{}{"id": 3, "name": "DRFL"}},
+				{"id": 2, "name": "Robbery", "era": map[string]interface{}{"id": 3, "name": "DRFL"}},
+			}})
+		},
+	})
+}
+
+func TestGetEraWithSongsFiltersByName(t *testing.T) {
+	api := newEraFilterAPI(t)
+	era, err := api.client().GetEraWithSongs(context.Background(), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if era.Name != "DRFL" || !reflect.DeepEqual(ids(era.Songs), []int{1, 2}) {
+		t.Errorf("GetEraWithSongs = %s %v", era.Name, ids(era.Songs))
+	}
+}
+
+func TestEraSongCountFiltersByName(t *testing.T) {
+	api := newEraFilterAPI(t)
+	ctx := context.Background()
+	c := api.client()
+	for _, era := range []Era{{ID: 3, Name: "DRFL"}, {ID: 3}} {
+		if n, err := era.SongCount(ctx, c); err != nil || n != 2 {
+			t.Errorf("%+v SongCount = %d, %v; want 2", era, n, err)
+		}
+	}
+	if n := api.hitCount("/juicewrld/songs/"); n != 2 {
+		t.Errorf("%d song requests for two counts, want one each", n)
+	}
+}
+
+func TestSongEraShapes(t *testing.T) {
+	api := newFixtureAPI(t, map[string]http.HandlerFunc{
+		"/juicewrld/songs/": payloadHandler(t, "songs-era-shapes.json"),
+	})
+	ctx := context.Background()
+	c := api.client(WithCache(0))
+
+	page, err := c.GetSongs(ctx, 1, nil, nil, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	songs := page.Results
+	wantIDs := []int{3, 5, 5, 0, 0, 99}
+	wantResolved := []bool{true, false, false, true, true, false}
+	for i, s := range songs {
+		if s.Era.ID != wantIDs[i] || s.EraResolved() != wantResolved[i] {
+			t.Errorf("song %d: era ID %d resolved %v, want %d %v", s.ID, s.Era.ID, s.EraResolved(), wantIDs[i], wantResolved[i])
+		}
+	}
+	if songs[0].Era.Name != "DRFL" || songs[0].Era.TimeFrame != "2017-2018" {
+		t.Errorf("embedded era = %+v", songs[0].Era)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := c.HydrateEras(ctx, songs); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var names []string
+	for _, s := range songs {
+		names = append(names, s.Era.Name)
+	}
+	if want := []string{"DRFL", "DSP", "DSP", "", "", ""}; !reflect.DeepEqual(names, want) {
+		t.Errorf("hydrated era names = %q, want %q", names, want)
+	}
+	if songs[5].Era.ID != 99 || songs[5].EraResolved() {
+		t.Errorf("unknown era = %+v, want its ID kept and left unresolved", songs[5].Era)
+	}
+	if n := api.hitCount("/juicewrld/eras/"); n != 1 {
+		t.Errorf("eras list fetched %d times, want once through the cache", n)
+	}
+}
+
+func TestEraRejectsOtherShapes(t *testing.T) {
+	for _, raw := range []string{`true`, `"DRFL"`, `1.5`, `[3]`} {
+		var e Era
+		if err := json.Unmarshal([]byte(raw), &e); err == nil {
+			t.Errorf("era %s decoded as %+v, want an error", raw, e)
+		}
+	}
+}
@@ -1,7 +1,10 @@
 package juicewrld
 
 import (
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 )
 
 type APIError struct {
@@ -23,3 +26,155 @@ type RateLimitError struct{ APIError }
 type NotFoundError struct{ APIError }
 type AuthenticationError struct{ APIError }
 type ValidationError struct{ APIError }
+
+// ConflictError reports a 409 response, such as cancelling a zip job that
+// has already finished. Message carries the server's explanation.
+type ConflictError struct{ APIError }
+
+var ErrConflict = errors.New("conflict")
+
+func (e *ConflictError) Is(target error) bool { return target == ErrConflict }
+
+type BudgetExhaustedError struct {
+	Err error
+}
+
+func (e *BudgetExhaustedError) Error() string {
+	return fmt.Sprintf("retry budget exhausted: %v", e.Err)
+}
+
+func (e *BudgetExhaustedError) Unwrap() error { return e.Err }
+
+type JobCancelledError struct {
+	JobID string
+}
+
+func (e *JobCancelledError) Error() string {
+	return fmt.Sprintf("zip job %s was cancelled", e.JobID)
+}
+
+type JobFailedError struct {
+	JobID  string
+	Status ZipJobStatus
+}
+
+func (e *JobFailedError) Error() string {
+	if e.Status.Error != "" {
+		return fmt.Sprintf("zip job %s failed: %s", e.JobID, e.Status.Error)
+	}
+	return fmt.Sprintf("zip job %s failed", e.JobID)
+}
+
+type CorruptDownloadError struct {
+	Path   string
+	Offset int64
+}
+
+func (e *CorruptDownloadError) Error() string {
+	return fmt.Sprintf("downloaded file %s does not match remote content at offset %d", e.Path, e.Offset)
+}
+
+var ErrZipJobTimeout = errors.New("zip job did not finish in time")
+
+type ZipJobTimeoutError struct {
+	JobID      string
+	LastStatus ZipJobStatus
+	Err        error
+}
+
+func (e *ZipJobTimeoutError) Error() string {
+	status := e.LastStatus.Status
+	if status == "" {
+		status = "unknown"
+	}
+	return fmt.Sprintf("zip job %s did not finish in time (last status: %s)", e.JobID, status)
+}
+
+func (e *ZipJobTimeoutError) Is(target error) bool { return target == ErrZipJobTimeout }
+
+func (e *ZipJobTimeoutError) Unwrap() error { return e.Err }
+
+type PinValidationError struct {
+	Host      string
+	Presented string
+}
+
+func (e *PinValidationError) Error() string {
+	if e.Presented == "" {
+		return fmt.Sprintf("certificate pinning failed for %s: no certificate presented", e.Host)
+	}
+	return fmt.Sprintf("certificate pinning failed for %s: presented key sha256/%s matches no pin", e.Host, e.Presented)
+}
+
+// AmbiguousMatchError is returned by FindSong when several songs fit the
+// hints about equally well. Candidates holds the songs within the
+// disambiguator's margin of the best score, best first.
+type AmbiguousMatchError struct {
+	Name       string
+	Candidates []SongCandidate
+}
+
+func (e *AmbiguousMatchError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d songs match %q:", len(e.Candidates), e.Name)
+	for _, c := range e.Candidates {
+		fmt.Fprintf(&b, " [#%d era=%q length=%q leak=%q]", c.Song.ID, c.Song.Era.Name, c.Song.Length, c.Song.LeakType)
+	}
+	return b.String()
+}
+
+// MissingFingerprintsError lists the files FindDuplicateFiles skipped
+// because the server has no fingerprint for them.
+type MissingFingerprintsError struct {
+	Paths []string
+}
+
+func (e *MissingFingerprintsError) Error() string {
+	return fmt.Sprintf("%d files have no fingerprint: %s", len(e.Paths), strings.Join(e.Paths, ", "))
+}
+
+// UnsizedPathsError lists the paths SelectionSize could not size, with the
+// reason for each.
+type UnsizedPathsError struct {
+	Paths map[string]error
+}
+
+func (e *UnsizedPathsError) Error() string {
+	paths := make([]string, 0, len(e.Paths))
+	for p := range e.Paths {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for i, p := range paths {
+		paths[i] = fmt.Sprintf("%s (%v)", p, e.Paths[p])
+	}
+	return fmt.Sprintf("%d paths could not be sized: %s", len(paths), strings.Join(paths, ", "))
+}
+
+// AmbiguousInstrumentalError is returned by DownloadSongInstrumentals when
+// several files match an instrumental title about equally well.
+type AmbiguousInstrumentalError struct {
+	SongID     int
+	Candidates []InstrumentalMatch
+}
+
+func (e *AmbiguousInstrumentalError) Error() string {
+	paths := make([]string, len(e.Candidates))
+	for i, c := range e.Candidates {
+		paths[i] = c.Path
+	}
+	return fmt.Sprintf("song %d: ambiguous instrumental files: %s", e.SongID, strings.Join(paths, ", "))
+}
+
+// UnexpectedContentTypeError is returned by downloads under
+// WithStrictContentType when the server sends an HTML page instead of the
+// file, usually because BaseURL points at a website rather than the API.
+type UnexpectedContentTypeError struct {
+	Path        string
+	URL         string
+	ContentType string
+}
+
+func (e *UnexpectedContentTypeError) Error() string {
+	return fmt.Sprintf("download of %s returned %s from %s; check that BaseURL points at the API", e.Path, e.ContentType, e.URL)
+}
@@ -0,0 +1,125 @@
+package juicewrld
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeAPI is an httptest server that routes by URL path and counts hits.
+// Unrouted paths go to fallback, or answer 404 like the real API.
+type fakeAPI struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	hits     map[string]int
+	routes   map[string]http.HandlerFunc
+	fallback http.HandlerFunc
+}
+
+func newFakeAPI(t *testing.T, routes map[string]http.HandlerFunc) *fakeAPI {
+	t.Helper()
+	f := &fakeAPI{hits: map[string]int{}, routes: routes}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		f.hits[r.URL.Path]++
+		h, ok := f.routes[r.URL.Path]
+		if !ok {
+			h = f.fallback
+		}
+		f.mu.Unlock()
+		if h == nil {
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": "not found"})
+			return
+		}
+		h(w, r)
+	}))
+	t.Cleanup(f.Close)
+	return f
+}
+
+// fixtureDir holds the snapshot written by internal/fixturegen, in the
+// layout WithOfflineSource reads.
+const fixtureDir = "testdata/mirror"
+
+// fixtureBaseURL is the host fixturegen writes into captured links.
+const fixtureBaseURL = "http://fixtures.invalid"
+
+// newFixtureAPI is newFakeAPI backed by the fixture snapshot: routes take
+// precedence and every other request is answered from fixtureDir, with
+// captured links pointed back at the server.
+func newFixtureAPI(t *testing.T, routes map[string]http.HandlerFunc) *fakeAPI {
+	t.Helper()
+	f := newFakeAPI(t, routes)
+	f.mu.Lock()
+	f.fallback = fixtureHandler(fixtureDir)
+	f.mu.Unlock()
+	return f
+}
+
+func fixtureHandler(dir string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name, isJSON, ok := offlineFile(strings.Trim(r.URL.Path, "/"), r.URL.Query())
+		if !ok {
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": "not found"})
+			return
+		}
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil {
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": "not found"})
+			return
+		}
+		if isJSON {
+			w.Header().Set("Content-Type", "application/json")
+			data = bytes.ReplaceAll(data, []byte(fixtureBaseURL), []byte("http://"+r.Host))
+		}
+		http.ServeContent(w, r, name, time.Time{}, bytes.NewReader(data))
+	}
+}
+
+func (f *fakeAPI) client(opts ...Option) *Client {
+	return New(f.URL, opts...)
+}
+
+func (f *fakeAPI) hitCount(path string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.hits[path]
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func jsonHandler(v interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) { writeJSON(w, http.StatusOK, v) }
+}
+
+func statusHandler(status int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, status, map[string]string{"error": http.StatusText(status)})
+	}
+}
+
+// payloadHandler serves testdata/payloads/name verbatim, for responses
+// whose exact bytes matter, such as nulls the snapshot cannot express.
+func payloadHandler(t *testing.T, name string) http.HandlerFunc {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "payloads", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	}
+}
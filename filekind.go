@@ -0,0 +1,203 @@
+package juicewrld
+
+import (
+	"mime"
+	"sort"
+	"strings"
+)
+
+// FileKind is the broad class of a file, as reported by KindOf.
+type FileKind int
+
+const (
+	FileKindOther FileKind = iota
+	FileKindAudio
+	FileKindVideo
+	FileKindImage
+	FileKindArchive
+	FileKindDocument
+)
+
+func (k FileKind) String() string {
+	switch k {
+	case FileKindAudio:
+		return "audio"
+	case FileKindVideo:
+		return "video"
+	case FileKindImage:
+		return "image"
+	case FileKindArchive:
+		return "archive"
+	case FileKindDocument:
+		return "document"
+	}
+	return "other"
+}
+
+var extKinds = map[string]FileKind{
+	"mp3": FileKindAudio, "m4a": FileKindAudio, "flac": FileKindAudio, "wav": FileKindAudio,
+	"ogg": FileKindAudio, "opus": FileKindAudio, "aac": FileKindAudio, "aif": FileKindAudio,
+	"aiff": FileKindAudio, "alac": FileKindAudio, "wma": FileKindAudio,
+
+	"mp4": FileKindVideo, "mov": FileKindVideo, "m4v": FileKindVideo, "mkv": FileKindVideo,
+	"webm": FileKindVideo, "avi": FileKindVideo,
+
+	"jpg": FileKindImage, "jpeg": FileKindImage, "png": FileKindImage, "gif": FileKindImage,
+	"webp": FileKindImage, "bmp": FileKindImage, "tif": FileKindImage, "tiff": FileKindImage,
+	"heic": FileKindImage,
+
+	"zip": FileKindArchive, "rar": FileKindArchive, "7z": FileKindArchive, "tar": FileKindArchive,
+	"gz": FileKindArchive, "tgz": FileKindArchive, "bz2": FileKindArchive, "xz": FileKindArchive,
+
+	"pdf": FileKindDocument, "txt": FileKindDocument, "md": FileKindDocument, "rtf": FileKindDocument,
+	"doc": FileKindDocument, "docx": FileKindDocument, "csv": FileKindDocument, "json": FileKindDocument,
+	"xml": FileKindDocument, "html": FileKindDocument, "htm": FileKindDocument, "log": FileKindDocument,
+	"nfo": FileKindDocument,
+}
+
+var mimeKinds = map[string]FileKind{
+	"application/zip":              FileKindArchive,
+	"application/x-zip-compressed": FileKindArchive,
+	"application/x-rar-compressed": FileKindArchive,
+	"application/vnd.rar":          FileKindArchive,
+	"application/x-7z-compressed":  FileKindArchive,
+	"application/x-tar":            FileKindArchive,
+	"application/gzip":             FileKindArchive,
+	"application/pdf":              FileKindDocument,
+	"application/json":             FileKindDocument,
+	"application/msword":           FileKindDocument,
+}
+
+// KindOf classifies a file by its extension, falling back to its MIME type
+// when the extension is missing or unknown. Containers that hold either
+// audio or video (mp4, ogg, webm) follow the MIME type when it says audio,
+// so an audio-only mp4 counts as audio. Directories are FileKindOther.
+func KindOf(fi FileInfo) FileKind {
+	if fi.IsDir() {
+		return FileKindOther
+	}
+	mt := fileMediaType(fi)
+	if kind, ok := extKinds[fi.Ext()]; ok {
+		if kind == FileKindVideo && strings.HasPrefix(mt, "audio/") {
+			return FileKindAudio
+		}
+		return kind
+	}
+	switch {
+	case strings.HasPrefix(mt, "audio/"):
+		return FileKindAudio
+	case strings.HasPrefix(mt, "video/"):
+		return FileKindVideo
+	case strings.HasPrefix(mt, "image/"):
+		return FileKindImage
+	case strings.HasPrefix(mt, "text/"):
+		return FileKindDocument
+	}
+	return mimeKinds[mt]
+}
+
+func fileMediaType(fi FileInfo) string {
+	mt, _, err := mime.ParseMediaType(fi.MimeType)
+	if err != nil {
+		return ""
+	}
+	return mt
+}
+
+// sourceTier groups playable formats by quality for PreferredSource.
+type sourceTier int
+
+const (
+	tierSnippet sourceTier = iota
+	tierLossy
+	tierLossless
+)
+
+// playableFormats is the IsPlayable allowlist in PreferredSource's order
+// of preference within each tier. mp4 and mov are how the archive stores
+// snippets.
+var playableFormats = []struct {
+	ext  string
+	tier sourceTier
+}{
+	{"flac", tierLossless},
+	{"wav", tierLossless},
+	{"m4a", tierLossy},
+	{"opus", tierLossy},
+	{"ogg", tierLossy},
+	{"mp3", tierLossy},
+	{"mp4", tierSnippet},
+	{"mov", tierSnippet},
+}
+
+var playableMIME = map[string]string{
+	"audio/flac": "flac", "audio/x-flac": "flac",
+	"audio/wav": "wav", "audio/x-wav": "wav", "audio/wave": "wav",
+	"audio/mp4": "m4a", "audio/x-m4a": "m4a",
+	"audio/opus": "opus",
+	"audio/ogg":  "ogg",
+	"audio/mpeg": "mp3", "audio/mp3": "mp3",
+	"video/mp4":       "mp4",
+	"video/quicktime": "mov",
+}
+
+// playableFormat returns the file's index in playableFormats, or -1. Files
+// without an extension are matched by MIME type.
+func playableFormat(fi FileInfo) int {
+	if fi.IsDir() {
+		return -1
+	}
+	ext := fi.Ext()
+	if ext == "" {
+		ext = playableMIME[fileMediaType(fi)]
+	}
+	for i, f := range playableFormats {
+		if f.ext == ext {
+			return i
+		}
+	}
+	return -1
+}
+
+// IsPlayable reports whether the file is in a format players can be
+// expected to handle: mp3, m4a, flac, wav, ogg and opus audio, plus mp4 and
+// mov for snippets.
+func IsPlayable(fi FileInfo) bool {
+	return playableFormat(fi) >= 0
+}
+
+// PreferredSource picks the best file to play from candidates holding the
+// same song: lossless (flac, wav) over lossy (m4a, opus, ogg, mp3) over
+// snippets (mp4, mov). Within a tier the larger file wins, as a stand-in
+// for bitrate, then the format order above, then the path, so the choice
+// doesn't depend on the order of files. It returns the zero FileInfo when
+// no candidate is playable.
+func PreferredSource(files []FileInfo) FileInfo {
+	type candidate struct {
+		fi     FileInfo
+		format int
+	}
+	var cands []candidate
+	for _, fi := range files {
+		if f := playableFormat(fi); f >= 0 {
+			cands = append(cands, candidate{fi, f})
+		}
+	}
+	if len(cands) == 0 {
+		return FileInfo{}
+	}
+	sort.SliceStable(cands, func(i, j int) bool {
+		a, b := cands[i], cands[j]
+		if ta, tb := playableFormats[a.format].tier, playableFormats[b.format].tier; ta != tb {
+			return ta > tb
+		}
+		if a.fi.Size != b.fi.Size {
+			return a.fi.Size > b.fi.Size
+		}
+		if a.format != b.format {
+			return a.format < b.format
+		}
+		return a.fi.Path < b.fi.Path
+	})
+	return cands[0].fi
+}
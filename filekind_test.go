@@ -0,0 +1,206 @@
+package juicewrld
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestKindOf(t *testing.T) {
+	tests := []struct {
+		fi   FileInfo
+		want FileKind
+	}{
+		{FileInfo{Name: "a.mp3"}, FileKindAudio},
+		{FileInfo{Name: "a.FLAC"}, FileKindAudio},
+		{FileInfo{Name: "a.mp4"}, FileKindVideo},
+		{FileInfo{Name: "a.mp4", MimeType: "audio/mp4"}, FileKindAudio},
+		{FileInfo{Name: "cover.jpg"}, FileKindImage},
+		{FileInfo{Name: "stems.zip"}, FileKindArchive},
+		{FileInfo{Name: "notes.txt"}, FileKindDocument},
+		{FileInfo{Name: "noext", MimeType: "audio/mpeg"}, FileKindAudio},
+		{FileInfo{Name: "noext", MimeType: "application/zip"}, FileKindArchive},
+		{FileInfo{Name: "Folder.mp3", Type: "directory"}, FileKindOther},
+		{FileInfo{Name: "a.xyz"}, FileKindOther},
+	}
+	for _, tt := range tests {
+		if got := KindOf(tt.fi); got != tt.want {
+			t.Errorf("KindOf(%s %q) = %v, want %v", tt.fi.Name, tt.fi.MimeType, got, tt.want)
+		}
+	}
+}
+
+func TestIsPlayable(t *testing.T) {
+	for _, name := range []string{"a.mp3", "a.m4a", "a.flac", "a.wav", "a.ogg", "a.opus", "a.mp4", "a.MOV"} {
+		if !IsPlayable(FileInfo{Name: name}) {
+			t.Errorf("IsPlayable(%s) = false", name)
+		}
+	}
+	for _, name := range []string{"a.aac", "a.wma", "a.mkv", "a.jpg", "a.zip", "noext"} {
+		if IsPlayable(FileInfo{Name: name}) {
+			t.Errorf("IsPlayable(%s) = true", name)
+		}
+	}
+	if !IsPlayable(FileInfo{Name: "noext", MimeType: "audio/mpeg"}) {
+		t.Error("extensionless audio/mpeg file not playable")
+	}
+	if IsPlayable(FileInfo{Name: "Album.mp3", Type: "directory"}) {
+		t.Error("directory reported playable")
+	}
+}
+
+func TestPreferredSourceRanking(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []FileInfo
+		want  string
+	}{
+		{"lossless beats larger lossy", []FileInfo{
+			{Name: "a.mp3", Path: "a.mp3", Size: 90 << 20},
+			{Name: "a.flac", Path: "a.flac", Size: 30 << 20},
+		}, "a.flac"},
+		{"lossy beats snippet", []FileInfo{
+			{Name: "a.mp4", Path: "a.mp4", Size: 90 << 20},
+			{Name: "a.ogg", Path: "a.ogg", Size: 1 << 20},
+		}, "a.ogg"},
+		{"larger file wins within a tier", []FileInfo{
+			{Name: "a.m4a", Path: "a.m4a", Size: 4 << 20},
+			{Name: "a.mp3", Path: "a.mp3", Size: 8 << 20},
+		}, "a.mp3"},
+		{"format order breaks size ties", []FileInfo{
+			{Name: "a.wav", Path: "a.wav", Size: 10},
+			{Name: "a.flac", Path: "a.flac", Size: 10},
+		}, "a.flac"},
+		{"path breaks full ties", []FileInfo{
+			{Name: "a.mp3", Path: "b/a.mp3", Size: 10},
+			{Name: "a.mp3", Path: "a/a.mp3", Size: 10},
+		}, "a/a.mp3"},
+		{"unplayable files are ignored", []FileInfo{
+			{Name: "a.aac", Path: "a.aac", Size: 99 << 20},
+			{Name: "a.mov", Path: "a.mov", Size: 1},
+		}, "a.mov"},
+		{"nothing playable", []FileInfo{{Name: "a.jpg", Path: "a.jpg"}}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, files := range permutations(tt.files) {
+				if got := PreferredSource(files).Path; got != tt.want {
+					t.Fatalf("PreferredSource(%v) = %q, want %q", files, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func permutations(files []FileInfo) [][]FileInfo {
+	if len(files) <= 1 {
+		return [][]FileInfo{files}
+	}
+	var out [][]FileInfo
+	for i := range files {
+		rest := append(append([]FileInfo{}, files[:i]...), files[i+1:]...)
+		for _, p := range permutations(rest) {
+			out = append(out, append([]FileInfo{files[i]}, p...))
+		}
+	}
+	return out
+}
+
+// sizedFiles answers bytes=0-0 probes of the download endpoint for the
+// given paths and 404s everything else.
+func sizedFiles(sizes map[string]int64) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		size, ok := sizes[r.URL.Query().Get("path")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Accept-Ranges", "bytes")
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes 0-0/%d", size))
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write([]byte{0})
+	}
+}
+
+func TestResolveBestAudioRanksWithPreferredSource(t *testing.T) {
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"/juicewrld/player/songs/1/": jsonHandler(map[string]interface{}{"title": "Song", "album": "Album"}),
+		"/juicewrld/files/download/": sizedFiles(map[string]int64{
+			"Compilation/1. Released Discography/Album/Song.mp3": 8 << 20,
+			"Compilation/2. Unreleased Discography/Song.flac":    30 << 20,
+			"Snippets/Song/Song.mp4":                             60 << 20,
+		}),
+	})
+	c := api.client()
+
+	info, err := c.ResolveBestAudio(context.Background(), 1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.FilePath != "Compilation/2. Unreleased Discography/Song.flac" || info.SongID != 1 {
+		t.Errorf("default ranking picked %+v, want the flac", info)
+	}
+
+	info, err = c.ResolveBestAudio(context.Background(), 1, []string{"mp4", "mp3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.FilePath != "Snippets/Song/Song.mp4" {
+		t.Errorf("explicit preference picked %s, want the mp4", info.FilePath)
+	}
+}
+
+func TestSearchInstrumentalKeepsSnippetFormats(t *testing.T) {
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"/juicewrld/files/browse/": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("path") != instrumentalsDir {
+				writeJSON(w, http.StatusOK, map[string]interface{}{"items": []FileInfo{}})
+				return
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{"items": []map[string]interface{}{
+				{"name": "Song (Instrumental).mp4", "type": "file", "path": "Instrumentals/Song (Instrumental).mp4", "mime_type": "video/mp4"},
+				{"name": "Song (Instrumental).jpg", "type": "file", "path": "Instrumentals/Song (Instrumental).jpg"},
+				{"name": "Song (Instrumental)", "type": "directory", "path": "Instrumentals/Song (Instrumental)"},
+			}})
+		},
+	})
+
+	matches, err := api.client().searchInstrumental(context.Background(), "Song (Instrumental)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 || matches[0].Path != "Instrumentals/Song (Instrumental).mp4" {
+		t.Errorf("matches = %+v, want only the mp4", matches)
+	}
+}
+
+func TestResolveBestAudioWithoutAlbum(t *testing.T) {
+	var probed []string
+	var mu sync.Mutex
+	sized := sizedFiles(map[string]int64{"Session Edits/Song.mp3": 1 << 20})
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"/juicewrld/player/songs/1/": jsonHandler(map[string]interface{}{"title": "Song", "album": nil}),
+		"/juicewrld/files/download/": func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			probed = append(probed, r.URL.Query().Get("path"))
+			mu.Unlock()
+			sized(w, r)
+		},
+	})
+
+	info, err := api.client().ResolveBestAudio(context.Background(), 1, []string{"mp3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.FilePath != "Session Edits/Song.mp3" {
+		t.Errorf("picked %s, want the session edit", info.FilePath)
+	}
+	for _, p := range probed {
+		if strings.Contains(p, "<nil>") || strings.HasPrefix(p, "Compilation/1.") {
+			t.Errorf("probed album path %q for a song without an album", p)
+		}
+	}
+}
@@ -0,0 +1,364 @@
+package juicewrld
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"reflect"
+	"strings"
+)
+
+var SkipDir = errors.New("skip this directory")
+
+const NoExtension = "(none)"
+
+// FileEncoding is a character encoding reported by the file endpoints.
+// Values other than the constants below, such as "iso-8859-1", pass through
+// as-is.
+type FileEncoding string
+
+const (
+	EncodingUTF8    FileEncoding = "utf-8"
+	EncodingASCII   FileEncoding = "ascii"
+	EncodingBinary  FileEncoding = "binary"
+	EncodingUnknown FileEncoding = "unknown"
+)
+
+func (f FileInfo) EncodingOrDefault() FileEncoding {
+	if f.Encoding == nil {
+		return EncodingUnknown
+	}
+	switch e := strings.ToLower(strings.TrimSpace(string(*f.Encoding))); e {
+	case "":
+		return EncodingUnknown
+	case "utf8", "utf-8":
+		return EncodingUTF8
+	case "us-ascii", "ascii":
+		return EncodingASCII
+	default:
+		return FileEncoding(e)
+	}
+}
+
+// IsBinary reports whether the server classified the file as binary data.
+func (f FileInfo) IsBinary() bool {
+	return f.EncodingOrDefault() == EncodingBinary
+}
+
+// IsText reports whether the server detected a character encoding for the
+// file. Files with no reported encoding are neither text nor binary.
+func (f FileInfo) IsText() bool {
+	switch f.EncodingOrDefault() {
+	case EncodingBinary, EncodingUnknown:
+		return false
+	}
+	return true
+}
+
+func (f FileInfo) IsDir() bool {
+	switch strings.ToLower(f.Type) {
+	case "directory", "dir", "folder":
+		return true
+	}
+	return false
+}
+
+func (f FileInfo) Ext() string {
+	ext := strings.TrimPrefix(f.Extension, ".")
+	if ext == "" {
+		ext = strings.TrimPrefix(path.Ext(f.Name), ".")
+	}
+	return strings.ToLower(ext)
+}
+
+func (c *Client) WalkFiles(ctx context.Context, root string, fn func(FileInfo) error) error {
+	ctx = withDefaultRetryBudget(ctx)
+	queue := []string{root}
+	visited := map[string]bool{}
+	for len(queue) > 0 {
+		dir := queue[0]
+		queue = queue[1:]
+		if visited[dir] {
+			continue
+		}
+		visited[dir] = true
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		listing, err := c.BrowseFiles(ctx, dir, nil)
+		if err != nil {
+			return err
+		}
+		for _, item := range listing.Items {
+			err := fn(item)
+			if errors.Is(err, SkipDir) {
+				if item.IsDir() {
+					continue
+				}
+				break
+			}
+			if err != nil {
+				return err
+			}
+			if item.IsDir() {
+				child := item.Path
+				if child == "" {
+					child = listing.Join(item.Name)
+				}
+				queue = append(queue, child)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Client) FileTypeBreakdown(ctx context.Context, root string) (map[string]int, error) {
+	out := make(map[string]int)
+	err := c.WalkFiles(ctx, root, func(f FileInfo) error {
+		if f.IsDir() {
+			return nil
+		}
+		ext := f.Ext()
+		if ext == "" {
+			ext = NoExtension
+		}
+		out[ext]++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FileExists reports whether filePath can be downloaded, probing it with a
+// one-byte ranged GET. A 404 is reported as false with a nil error, and a
+// 416 (the answer for an empty file) as true; other failures are returned
+// as errors.
+func (c *Client) FileExists(ctx context.Context, filePath string) (bool, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.downloadURL(filePath), nil, "")
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Range", "bytes=0-0")
+	resp, err := c.sendRetrying(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		return false, nil
+	case http.StatusRequestedRangeNotSatisfiable:
+		return true, nil
+	}
+	if err := checkResponse(resp); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ValidatePaths probes paths with FileExists and partitions them, keeping
+// their input order, so a zip job can be started with only the paths that
+// exist. A failed probe does not stop the others: its path is left out of
+// both partitions and its error, prefixed with the path, is joined into err.
+func (c *Client) ValidatePaths(ctx context.Context, paths []string) (valid []string, invalid []string, err error) {
+	exists := make([]bool, len(paths))
+	errs := make([]error, len(paths))
+	probed := make([]bool, len(paths))
+	err = runConcurrent(withDefaultRetryBudget(ctx), len(paths), c.concurrencyLimit(), func(ctx context.Context, i int) error {
+		exists[i], errs[i] = c.FileExists(ctx, paths[i])
+		probed[i] = true
+		return nil
+	})
+	var failed []error
+	for i, p := range paths {
+		switch {
+		case !probed[i]:
+		case errs[i] != nil:
+			failed = append(failed, fmt.Errorf("%s: %w", p, errs[i]))
+		case exists[i]:
+			valid = append(valid, p)
+		default:
+			invalid = append(invalid, p)
+		}
+	}
+	if err != nil {
+		return valid, invalid, err
+	}
+	return valid, invalid, errors.Join(failed...)
+}
+
+// BrowseFilesStream is BrowseFiles for very large listings: items are
+// decoded one at a time from the response body and passed to fn, so the
+// listing is never held in memory. Items that fail to decode are skipped,
+// as in DirectoryInfo. An error from fn stops the stream and is returned;
+// returning SkipDir stops it without an error.
+func (c *Client) BrowseFilesStream(ctx context.Context, path string, search *string, fn func(FileInfo) error) error {
+	q := url.Values{}
+	if path != "" {
+		q.Set("path", c.normalizePath(path))
+	}
+	if search != nil {
+		if s := c.normalizeSearch(*search); s != "" {
+			q.Set("search", s)
+		}
+	}
+	req, err := c.newRequest(ctx, http.MethodGet, c.endpointURL("/juicewrld/files/browse/", q), nil, "application/json")
+	if err != nil {
+		return err
+	}
+	resp, err := c.sendRetrying(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp); err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(resp.Body)
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if key, _ := tok.(string); key != "items" {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return err
+			}
+			continue
+		}
+		err = c.streamItems(dec, fn)
+		if errors.Is(err, SkipDir) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Client) streamItems(dec *json.Decoder, fn func(FileInfo) error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if tok != json.Delim('[') {
+		return fmt.Errorf("browse items: expected array, got %v", tok)
+	}
+	for dec.More() {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return err
+		}
+		f, err := decodeFileItem(raw)
+		if err != nil {
+			continue
+		}
+		if c.sanitizeStrings {
+			sanitizeValue(reflect.ValueOf(&f), false)
+		}
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+	_, err = dec.Token()
+	return err
+}
+
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != want {
+		return fmt.Errorf("expected %v, got %v", want, tok)
+	}
+	return nil
+}
+
+var errSizeUnknown = errors.New("size unknown")
+
+// SelectionSize sums the sizes of the given files, fetching their info
+// concurrently. Directories are skipped; use SelectionSizeRecursive to
+// count their contents. Paths that can't be sized are reported in an
+// *UnsizedPathsError returned alongside the total of the rest.
+func (c *Client) SelectionSize(ctx context.Context, paths []string) (int64, error) {
+	return c.selectionSize(ctx, paths, false)
+}
+
+// SelectionSizeRecursive is SelectionSize with directories counted as the
+// total size of the files beneath them.
+func (c *Client) SelectionSizeRecursive(ctx context.Context, paths []string) (int64, error) {
+	return c.selectionSize(ctx, paths, true)
+}
+
+func (c *Client) selectionSize(ctx context.Context, paths []string, recursive bool) (int64, error) {
+	ctx = withDefaultRetryBudget(ctx)
+	sizes := make([]int64, len(paths))
+	errs := make([]error, len(paths))
+	err := runConcurrent(ctx, len(paths), c.concurrencyLimit(), func(ctx context.Context, i int) error {
+		sizes[i], errs[i] = c.pathSize(ctx, paths[i], recursive)
+		return nil
+	})
+	if err == nil {
+		err = ctx.Err()
+	}
+	var total int64
+	unsized := map[string]error{}
+	for i, p := range paths {
+		if errs[i] != nil {
+			unsized[p] = errs[i]
+			continue
+		}
+		total += sizes[i]
+	}
+	if err != nil {
+		return total, err
+	}
+	if len(unsized) > 0 {
+		return total, &UnsizedPathsError{Paths: unsized}
+	}
+	return total, nil
+}
+
+func (c *Client) pathSize(ctx context.Context, p string, recursive bool) (int64, error) {
+	info, err := c.GetFileInfo(ctx, p)
+	if err != nil {
+		return 0, err
+	}
+	if !info.IsDir() {
+		if !info.SizeKnown() {
+			return 0, errSizeUnknown
+		}
+		return info.Size, nil
+	}
+	if !recursive {
+		return 0, nil
+	}
+	var total int64
+	err = c.WalkFiles(ctx, p, func(f FileInfo) error {
+		if f.IsDir() {
+			return nil
+		}
+		if !f.SizeKnown() {
+			return fmt.Errorf("%s: %w", f.Path, errSizeUnknown)
+		}
+		total += f.Size
+		return nil
+	})
+	return total, err
+}
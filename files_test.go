@@ -0,0 +1,115 @@
+package juicewrld
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// probeStatuses answers download probes with a status per path.
+func probeStatuses(statuses map[string]int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		status, ok := statuses[r.URL.Query().Get("path")]
+		if !ok {
+			status = http.StatusNotFound
+		}
+		if status == http.StatusPartialContent {
+			w.Header().Set("Content-Range", "bytes 0-0/10")
+			w.WriteHeader(status)
+			w.Write([]byte{0})
+			return
+		}
+		statusHandler(status)(w, r)
+	}
+}
+
+func TestValidatePathsKeepsGoingAfterFailure(t *testing.T) {
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"/juicewrld/files/download/": probeStatuses(map[string]int{
+			"a.mp3":     http.StatusPartialContent,
+			"empty.txt": http.StatusRequestedRangeNotSatisfiable,
+			"broken":    http.StatusForbidden,
+			"c.mp3":     http.StatusPartialContent,
+		}),
+	})
+	paths := []string{"broken", "a.mp3", "missing.mp3", "empty.txt", "c.mp3"}
+
+	valid, invalid, err := api.client().ValidatePaths(context.Background(), paths)
+	if want := []string{"a.mp3", "empty.txt", "c.mp3"}; !reflect.DeepEqual(valid, want) {
+		t.Errorf("valid = %q, want %q", valid, want)
+	}
+	if want := []string{"missing.mp3"}; !reflect.DeepEqual(invalid, want) {
+		t.Errorf("invalid = %q, want %q", invalid, want)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden || !strings.HasPrefix(err.Error(), "broken: ") {
+		t.Errorf("err = %v, want the forbidden probe of broken", err)
+	}
+}
+
+func TestFileExistsTreatsUnsatisfiableRangeAsExisting(t *testing.T) {
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"/juicewrld/files/download/": probeStatuses(map[string]int{"empty.txt": http.StatusRequestedRangeNotSatisfiable}),
+	})
+	ok, err := api.client().FileExists(context.Background(), "empty.txt")
+	if err != nil || !ok {
+		t.Errorf("FileExists(empty file) = %v, %v; want true, nil", ok, err)
+	}
+}
+
+func TestBrowseFilesToleratesNulls(t *testing.T) {
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"/juicewrld/files/browse/": payloadHandler(t, "browse-nulls.json"),
+	})
+	ctx := context.Background()
+	c := api.client()
+
+	dir, err := c.BrowseFiles(ctx, "Unsurfaced/Sessions", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantSizes := map[string]int64{
+		"2018":                     -1,
+		"Stay High (Session).wav":  -1,
+		"Wandered To LA (Ref).mp3": 4821390,
+		"Bad Boy (Snippet).m4a":    880211,
+	}
+	if len(dir.Items) != len(wantSizes) {
+		t.Fatalf("decoded %d items, want %d", len(dir.Items), len(wantSizes))
+	}
+	for _, f := range dir.Items {
+		want, ok := wantSizes[f.Name]
+		if !ok || f.Size != want || f.SizeKnown() != (want >= 0) {
+			t.Errorf("%q: size %d known %v, want %d", f.Name, f.Size, f.SizeKnown(), want)
+		}
+		if (f.Created != nil) != (f.Name == "Wandered To LA (Ref).mp3") || (f.Modified != nil) != (f.Created != nil) {
+			t.Errorf("%q: created %v modified %v", f.Name, f.Created, f.Modified)
+		}
+	}
+	if dir.TotalFiles != 4 || dir.TotalDirectories != 1 || len(dir.Breadcrumbs) != 2 {
+		t.Errorf("listing = %d files, %d dirs, breadcrumbs %v", dir.TotalFiles, dir.TotalDirectories, dir.Breadcrumbs)
+	}
+
+	if len(dir.DecodeWarnings) != 2 {
+		t.Fatalf("DecodeWarnings = %+v, want the bad size and the null item", dir.DecodeWarnings)
+	}
+	if w := dir.DecodeWarnings[0]; w.Index != 3 || !strings.Contains(w.Message, "invalid size") || !strings.Contains(w.Raw, "corrupt.mp3") {
+		t.Errorf("first warning = %+v", w)
+	}
+	if w := dir.DecodeWarnings[1]; w.Index != 4 || w.Raw != "null" {
+		t.Errorf("second warning = %+v", w)
+	}
+
+	var streamed []string
+	err = c.BrowseFilesStream(ctx, "Unsurfaced/Sessions", nil, func(f FileInfo) error {
+		streamed = append(streamed, f.Name)
+		return nil
+	})
+	want := []string{"2018", "Stay High (Session).wav", "Wandered To LA (Ref).mp3", "Bad Boy (Snippet).m4a"}
+	if err != nil || !reflect.DeepEqual(streamed, want) {
+		t.Errorf("BrowseFilesStream = %q, %v; want %q", streamed, err, want)
+	}
+}
@@ -0,0 +1,361 @@
+package juicewrld
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+type SongFilter struct {
+	Category string
+	Era      string
+	Eras     []string
+	Search   string
+	Page     int
+	PageSize int
+	// ModifiedSince is sent as modified_since when non-zero. Servers that
+	// don't support the parameter ignore it; SyncSongs detects that case.
+	ModifiedSince time.Time
+	// ExcludeCategories drops songs in any of these categories, compared
+	// case-insensitively, and composes with Category. The API has no
+	// negative filter, so the songs are removed client-side as each page
+	// arrives: a page can hold fewer than PageSize songs, and reports them
+	// in Dropped, while its Count, next/previous links and TotalPages still
+	// describe the server's pages.
+	ExcludeCategories []string
+	// IncludeRemoved keeps songs taken down from the catalog (see
+	// Song.IsRemoved), which are dropped client-side by default, with the
+	// same effect on page sizes as ExcludeCategories.
+	IncludeRemoved bool
+}
+
+// filterSupport remembers whether the server honours a query filter, once
+// a response has shown it either way, for the client's lifetime.
+type filterSupport struct {
+	state atomic.Int32
+}
+
+const (
+	filterUnknown int32 = iota
+	filterSupported
+	filterIgnored
+)
+
+// known returns the remembered answer; ok is false while it is unknown.
+func (f *filterSupport) known() (supported, ok bool) {
+	switch f.state.Load() {
+	case filterSupported:
+		return true, true
+	case filterIgnored:
+		return false, true
+	}
+	return false, false
+}
+
+func (f *filterSupport) set(supported bool) {
+	if supported {
+		f.state.Store(filterSupported)
+	} else {
+		f.state.Store(filterIgnored)
+	}
+}
+
+func (f *SongFilter) ToQueryValues() url.Values {
+	q := url.Values{}
+	if f == nil {
+		return q
+	}
+	if f.Page > 0 {
+		q.Set("page", strconv.Itoa(f.Page))
+	}
+	if f.PageSize > 0 {
+		q.Set("page_size", strconv.Itoa(f.PageSize))
+	}
+	if f.Category != "" {
+		q.Set("category", f.Category)
+	}
+	if eras := f.eraList(); len(eras) == 1 {
+		q.Set("era", eras[0])
+	}
+	if s := NormalizeSearchQuery(f.Search); s != "" {
+		q.Set("search", s)
+	}
+	if !f.ModifiedSince.IsZero() {
+		q.Set("modified_since", f.ModifiedSince.UTC().Format(time.RFC3339))
+	}
+	return q
+}
+
+// exclude removes the songs ExcludeCategories and IncludeRemoved rule
+// out. A nil filter drops removed songs only. songs is returned unchanged
+// when nothing is dropped.
+func (f *SongFilter) exclude(songs Songs) Songs {
+	var excluded map[string]bool
+	includeRemoved := false
+	if f != nil {
+		includeRemoved = f.IncludeRemoved
+		if len(f.ExcludeCategories) > 0 {
+			excluded = make(map[string]bool, len(f.ExcludeCategories))
+			for _, c := range f.ExcludeCategories {
+				excluded[foldString(c)] = true
+			}
+		}
+	}
+	keep := func(s Song) bool {
+		return (includeRemoved || !s.IsRemoved()) && !excluded[foldString(s.Category)]
+	}
+	for i, s := range songs {
+		if keep(s) {
+			continue
+		}
+		out := append(make(Songs, 0, len(songs)-1), songs[:i]...)
+		for _, s := range songs[i+1:] {
+			if keep(s) {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return songs
+}
+
+func (f *SongFilter) requestPageSize() int {
+	if f == nil || f.PageSize < 0 {
+		return 0
+	}
+	return f.PageSize
+}
+
+func (f *SongFilter) eraList() []string {
+	if f == nil {
+		return nil
+	}
+	var out []string
+	seen := map[string]bool{}
+	for _, e := range append([]string{f.Era}, f.Eras...) {
+		if e == "" || seen[e] {
+			continue
+		}
+		seen[e] = true
+		out = append(out, e)
+	}
+	return out
+}
+
+func (f *SongFilter) perEra() []*SongFilter {
+	eras := f.eraList()
+	if len(eras) <= 1 {
+		return []*SongFilter{f}
+	}
+	out := make([]*SongFilter, len(eras))
+	for i, e := range eras {
+		cp := *f
+		cp.Era = e
+		cp.Eras = nil
+		out[i] = &cp
+	}
+	return out
+}
+
+// ListSongs returns one page of the songs matching filter. With several
+// eras, the page is requested from each era concurrently and the results
+// are merged without duplicates. Count is then the sum of the eras'
+// counts, and Next and Previous are nil, as no one link continues the
+// merged listing; use GetAllSongs or IterateSongs to page across eras. If
+// an era's request fails, the songs of the eras that completed are
+// returned together with the error.
+func (c *Client) ListSongs(ctx context.Context, filter *SongFilter) (PaginatedSongsResponse, error) {
+	filters := filter.perEra()
+	if len(filters) == 1 {
+		var out PaginatedSongsResponse
+		err := c.getPage(ctx, "/juicewrld/songs/", c.songFilterQuery(filters[0]), &out)
+		c.excludePage(filter, &out)
+		out.pageSize = filter.requestPageSize()
+		return out, err
+	}
+
+	pages := make([]PaginatedSongsResponse, len(filters))
+	done := make([]bool, len(filters))
+	err := runConcurrent(withDefaultRetryBudget(ctx), len(filters), defaultConcurrency, func(ctx context.Context, i int) error {
+		if err := c.getPage(ctx, "/juicewrld/songs/", c.songFilterQuery(filters[i]), &pages[i]); err != nil {
+			return err
+		}
+		done[i] = true
+		return nil
+	})
+	var out PaginatedSongsResponse
+	results := make([]Songs, len(pages))
+	for i, p := range pages {
+		if !done[i] {
+			continue
+		}
+		c.excludePage(filter, &p)
+		results[i] = p.Results
+		out.Count += p.Count
+		out.dropped += p.dropped
+	}
+	out.Results = mergeUniqueSongs(results...)
+	out.pageSize = filter.requestPageSize()
+	return out, err
+}
+
+// GetAllSongs follows pagination to collect every matching song. If a
+// request fails or ctx is cancelled midway, the songs collected so far are
+// returned together with the error.
+func (c *Client) GetAllSongs(ctx context.Context, filter *SongFilter) (Songs, error) {
+	filters := filter.perEra()
+	ctx = withDefaultRetryBudget(ctx)
+	results := make([]Songs, len(filters))
+	err := runConcurrent(ctx, len(filters), defaultConcurrency, func(ctx context.Context, i int) error {
+		q := c.songFilterQuery(filters[i])
+		q.Del("page")
+		songs, err := NewPaginator[Song](c, "/juicewrld/songs/", q).All(ctx)
+		results[i] = c.exclude(filters[i], songs)
+		return err
+	})
+	return mergeUniqueSongs(results...), err
+}
+
+// GetSongsRange fetches pages startPage through endPage (inclusive)
+// concurrently and returns their songs in page order. Pages past the end of
+// the catalog contribute nothing, so shards can be sized generously. On
+// error or cancellation the songs of the leading pages that completed are
+// returned with the error, so a worker can resume after them.
+func (c *Client) GetSongsRange(ctx context.Context, filter *SongFilter, startPage, endPage int) ([]Song, error) {
+	if startPage < 1 || endPage < startPage {
+		return nil, &ValidationError{APIError{Message: fmt.Sprintf("invalid page range %d-%d", startPage, endPage)}}
+	}
+	ctx = withDefaultRetryBudget(ctx)
+	pages := make([]Songs, endPage-startPage+1)
+	done := make([]bool, len(pages))
+	err := runConcurrent(ctx, len(pages), c.concurrencyLimit(), func(ctx context.Context, i int) error {
+		f := SongFilter{}
+		if filter != nil {
+			f = *filter
+		}
+		f.Page = startPage + i
+		page, err := c.ListSongs(ctx, &f)
+		var notFound *NotFoundError
+		if errors.As(err, &notFound) {
+			done[i] = true
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		pages[i], done[i] = page.Results, true
+		return nil
+	})
+	var out []Song
+	for i, p := range pages {
+		if !done[i] {
+			break
+		}
+		out = append(out, p...)
+	}
+	return out, err
+}
+
+func mergeUniqueSongs(lists ...Songs) Songs {
+	var out Songs
+	seen := map[int]bool{}
+	for _, list := range lists {
+		for _, s := range list {
+			if seen[s.ID] {
+				continue
+			}
+			seen[s.ID] = true
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// GetSongsWithDetails fetches a page of songs and fills each in from its
+// detail record. On error or cancellation, the songs whose details were
+// fetched are returned, in page order, together with the error.
+func (c *Client) GetSongsWithDetails(ctx context.Context, filter *SongFilter) (Songs, error) {
+	ctx = withDefaultRetryBudget(ctx)
+	page, err := c.ListSongs(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	out := make(Songs, len(page.Results))
+	done := make([]bool, len(page.Results))
+	err = runConcurrent(ctx, len(page.Results), c.concurrencyLimit(), func(ctx context.Context, i int) error {
+		full, err := c.GetSong(ctx, page.Results[i].ID)
+		if err != nil {
+			return err
+		}
+		out[i], done[i] = mergeSong(page.Results[i], full), true
+		return nil
+	})
+	if err != nil {
+		var partial Songs
+		for i, s := range out {
+			if done[i] {
+				partial = append(partial, s)
+			}
+		}
+		return partial, err
+	}
+	return out, nil
+}
+
+// filteredPage fetches a page of songs with the server-side filter in q.
+// honoured reports whether a page's results respect that filter; when they
+// don't, the page is built from keep applied to the cached catalog instead.
+// Once a non-empty page shows either way, support remembers it, and a
+// server known to ignore the filter is not asked again.
+func (c *Client) filteredPage(ctx context.Context, support *filterSupport, q url.Values, page, pageSize int, honoured func(Songs) bool, keep func(Songs) Songs) (PaginatedSongsResponse, error) {
+	if supported, ok := support.known(); !ok || supported {
+		if page > 0 {
+			q.Set("page", strconv.Itoa(page))
+		}
+		if pageSize > 0 {
+			q.Set("page_size", strconv.Itoa(pageSize))
+		}
+		var out PaginatedSongsResponse
+		if err := c.getPage(ctx, "/juicewrld/songs/", q, &out); err != nil {
+			return PaginatedSongsResponse{}, err
+		}
+		out.pageSize = max(pageSize, 0)
+		if len(out.Results) == 0 || honoured(out.Results) {
+			if len(out.Results) > 0 {
+				support.set(true)
+			}
+			c.excludePage(nil, &out)
+			return out, nil
+		}
+		support.set(false)
+		if pageSize <= 0 && out.Next != nil {
+			c.serverPageSize.Store(int32(len(out.Results)))
+		}
+	}
+	all, err := c.cachedCatalog(ctx)
+	if err != nil {
+		return PaginatedSongsResponse{}, err
+	}
+	if pageSize <= 0 {
+		pageSize = int(c.serverPageSize.Load())
+	}
+	out := pageLocally(keep(all), page, pageSize)
+	c.excludePage(nil, &out)
+	return out, nil
+}
+
+// pageLocally serves a page of songs client-side for endpoints whose
+// filter the server ignored. A pageSize of 0 returns every song as one
+// page. The result carries no next/previous links.
+func pageLocally(songs Songs, page, pageSize int) PaginatedSongsResponse {
+	start, end := 0, len(songs)
+	if pageSize > 0 {
+		start = min(max(page-1, 0)*pageSize, len(songs))
+		end = min(start+pageSize, len(songs))
+	}
+	return PaginatedSongsResponse{Results: songs[start:end], Count: len(songs), pageSize: max(pageSize, 0)}
+}
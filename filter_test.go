@@ -0,0 +1,81 @@
+package juicewrld
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// newMultiEraAPI serves DRFL over two pages (songs 1 and 2, then 3) and
+// WOD as one page (songs 2 and 4, 2 being credited to both eras). The era
+// "missing" is answered with 404 once DRFL has been served.
+func newMultiEraAPI(t *testing.T) *fakeAPI {
+	t.Helper()
+	drflServed := make(chan struct{}, 1)
+	var api *fakeAPI
+	api = newFakeAPI(t, map[string]http.HandlerFunc{
+		"/juicewrld/songs/": func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query()
+			song := func(id int) map[string]interface{} {
+				return map[string]interface{}{"id": id, "name": fmt.Sprint("Song ", id)}
+			}
+			switch {
+			case q.Get("era") == "DRFL" && q.Get("page") == "2":
+				prev := api.URL + "/juicewrld/songs/?era=DRFL"
+				writeJSON(w, http.StatusOK, map[string]interface{}{"count": 3, "previous": prev, "results": []interface{}{song(3)}})
+			case q.Get("era") == "DRFL":
+				next := api.URL + "/juicewrld/songs/?era=DRFL&page=2"
+				writeJSON(w, http.StatusOK, map[string]interface{}{"count": 3, "next": next, "results": []interface{}{song(1), song(2)}})
+				select {
+				case drflServed <- struct{}{}:
+				default:
+				}
+			case q.Get("era") == "WOD":
+				writeJSON(w, http.StatusOK, map[string]interface{}{"count": 2, "results": []interface{}{song(2), song(4)}})
+			default:
+				<-drflServed
+				time.Sleep(50 * time.Millisecond)
+				statusHandler(http.StatusNotFound)(w, r)
+			}
+		},
+	})
+	return api
+}
+
+func TestListSongsMergesErasWithoutLinks(t *testing.T) {
+	api := newMultiEraAPI(t)
+	page, err := api.client().ListSongs(context.Background(), &SongFilter{Eras: []string{"DRFL", "WOD"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ids(page.Results), []int{1, 2, 4}) || page.Count != 5 {
+		t.Errorf("ListSongs = %v count %d, want [1 2 4] count 5", ids(page.Results), page.Count)
+	}
+	if page.Next != nil || page.Previous != nil {
+		t.Errorf("merged page links = %v, %v; want none", page.Next, page.Previous)
+	}
+}
+
+func TestListSongsReturnsCompletedErasWithError(t *testing.T) {
+	api := newMultiEraAPI(t)
+	page, err := api.client().ListSongs(context.Background(), &SongFilter{Eras: []string{"DRFL", "missing"}})
+	var notFound *NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Errorf("err = %v, want the missing era's NotFoundError", err)
+	}
+	if !reflect.DeepEqual(ids(page.Results), []int{1, 2}) || page.Count != 3 {
+		t.Errorf("partial page = %v count %d, want DRFL's [1 2] count 3", ids(page.Results), page.Count)
+	}
+}
+
+func TestIterateSongsPagesEachEra(t *testing.T) {
+	api := newMultiEraAPI(t)
+	songs, err := api.client().IterateSongs(context.Background(), &SongFilter{Eras: []string{"DRFL", "WOD"}}).Collect()
+	if err != nil || !reflect.DeepEqual(ids(songs), []int{1, 2, 3, 4}) {
+		t.Errorf("IterateSongs = %v, %v; want [1 2 3 4]", ids(songs), err)
+	}
+}
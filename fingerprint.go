@@ -0,0 +1,182 @@
+package juicewrld
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/bits"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// fingerprintMaxShift is how many frames Similarity slides one fingerprint
+// against the other, absorbing small differences in leading silence.
+const fingerprintMaxShift = 80
+
+// Fingerprint is an acoustic fingerprint of an audio file. For the
+// chromaprint algorithm Data holds the raw 32-bit sub-fingerprints.
+type Fingerprint struct {
+	Algorithm string   `json:"algorithm"`
+	Duration  float64  `json:"duration"`
+	Data      []uint32 `json:"data"`
+}
+
+// UnmarshalJSON accepts data as an array of (signed or unsigned) integers
+// or as a comma-separated string of them.
+func (f *Fingerprint) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		Algorithm string          `json:"algorithm"`
+		Duration  float64         `json:"duration"`
+		Data      json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	f.Algorithm, f.Duration, f.Data = raw.Algorithm, raw.Duration, nil
+	if len(raw.Data) == 0 || string(raw.Data) == "null" {
+		return nil
+	}
+	var nums []int64
+	if err := json.Unmarshal(raw.Data, &nums); err != nil {
+		var text string
+		if json.Unmarshal(raw.Data, &text) != nil {
+			return err
+		}
+		for _, field := range strings.Split(text, ",") {
+			if field = strings.TrimSpace(field); field == "" {
+				continue
+			}
+			n, err := strconv.ParseInt(field, 10, 64)
+			if err != nil {
+				return err
+			}
+			nums = append(nums, n)
+		}
+	}
+	f.Data = make([]uint32, len(nums))
+	for i, n := range nums {
+		f.Data[i] = uint32(n)
+	}
+	return nil
+}
+
+// Similarity compares two chromaprint fingerprints by bit error rate at the
+// best alignment within a small shift, returning 1 for identical audio and
+// about 0.5 for unrelated audio. Fingerprints of different algorithms, or
+// too short to overlap meaningfully, score 0.
+func (f Fingerprint) Similarity(other Fingerprint) float64 {
+	if !strings.EqualFold(f.Algorithm, other.Algorithm) || len(f.Data) == 0 || len(other.Data) == 0 {
+		return 0
+	}
+	minOverlap := min(len(f.Data), len(other.Data)) / 2
+	minOverlap = max(minOverlap, 1)
+	best := 0.0
+	for shift := -fingerprintMaxShift; shift <= fingerprintMaxShift; shift++ {
+		a, b := f.Data, other.Data
+		if shift > 0 {
+			if shift >= len(a) {
+				continue
+			}
+			a = a[shift:]
+		} else if shift < 0 {
+			if -shift >= len(b) {
+				continue
+			}
+			b = b[-shift:]
+		}
+		n := min(len(a), len(b))
+		if n < minOverlap {
+			continue
+		}
+		diff := 0
+		for i := 0; i < n; i++ {
+			diff += bits.OnesCount32(a[i] ^ b[i])
+		}
+		if sim := 1 - float64(diff)/float64(n*32); sim > best {
+			best = sim
+		}
+	}
+	return best
+}
+
+func (c *Client) GetFileFingerprint(ctx context.Context, filePath string) (Fingerprint, error) {
+	var out Fingerprint
+	err := c.get(ctx, "/juicewrld/files/fingerprint/", url.Values{"path": {filePath}}, &out)
+	return out, err
+}
+
+// FindDuplicateFiles fetches fingerprints for paths with bounded
+// concurrency and groups files whose similarity reaches threshold. Only
+// groups of two or more are returned, in input order. Files without a
+// fingerprint are left out and listed in a *MissingFingerprintsError that is
+// returned alongside the groups.
+func (c *Client) FindDuplicateFiles(ctx context.Context, paths []string, threshold float64) ([][]string, error) {
+	ctx = withDefaultRetryBudget(ctx)
+	prints := make([]Fingerprint, len(paths))
+	missing := make([]bool, len(paths))
+	err := runConcurrent(ctx, len(paths), c.concurrencyLimit(), func(ctx context.Context, i int) error {
+		fp, err := c.GetFileFingerprint(ctx, paths[i])
+		var notFound *NotFoundError
+		switch {
+		case errors.As(err, &notFound):
+			missing[i] = true
+			return nil
+		case err != nil:
+			return err
+		}
+		prints[i] = fp
+		missing[i] = len(fp.Data) == 0
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	parent := make([]int, len(paths))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	for i := range paths {
+		for j := i + 1; j < len(paths); j++ {
+			if missing[i] || missing[j] || find(i) == find(j) {
+				continue
+			}
+			if prints[i].Similarity(prints[j]) >= threshold {
+				parent[find(j)] = find(i)
+			}
+		}
+	}
+
+	groups := map[int][]string{}
+	var order []int
+	var skipped []string
+	for i, p := range paths {
+		if missing[i] {
+			skipped = append(skipped, p)
+			continue
+		}
+		root := find(i)
+		if _, ok := groups[root]; !ok {
+			order = append(order, root)
+		}
+		groups[root] = append(groups[root], p)
+	}
+	var out [][]string
+	for _, root := range order {
+		if len(groups[root]) > 1 {
+			out = append(out, groups[root])
+		}
+	}
+	if len(skipped) > 0 {
+		return out, &MissingFingerprintsError{Paths: skipped}
+	}
+	return out, nil
+}
@@ -0,0 +1,75 @@
+package juicewrld
+
+import (
+	"context"
+	"testing"
+)
+
+// TestFixtureSnapshotDecodes runs the decoders over the captured snapshot,
+// both served over HTTP and read through WithOfflineSource.
+func TestFixtureSnapshotDecodes(t *testing.T) {
+	api := newFixtureAPI(t, nil)
+	clients := map[string]*Client{
+		"http":    api.client(),
+		"offline": New("http://offline.invalid", WithOfflineSource(fixtureDir)),
+	}
+	for name, c := range clients {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			songs, err := c.GetAllSongs(ctx, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(songs) != 3 {
+				t.Fatalf("GetAllSongs returned %d songs, want 3 across both pages", len(songs))
+			}
+			song, err := c.GetSong(ctx, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if song.Name != "Lucid Dreams" || song.Era.Name != "DRFL" || len(song.InstrumentalNames) != 1 {
+				t.Errorf("GetSong(1) = %+v", song)
+			}
+			if songs[1].FileNames.String() != "All Girls Are The Same.mp3" || songs[2].TrackTitles.String() != "Robbery" {
+				t.Errorf("string-or-list fields decoded as %q and %q", songs[1].FileNames, songs[2].TrackTitles)
+			}
+
+			albums, err := c.GetAlbums(ctx)
+			if err != nil || len(albums) != 1 || albums[0].Artist.Name != "Juice WRLD" {
+				t.Errorf("GetAlbums = %+v, %v", albums, err)
+			}
+			if len(albums) == 1 && albums[0].ReleaseDate.Year() != 2018 {
+				t.Errorf("album release date = %v, want 2018", albums[0].ReleaseDate)
+			}
+			if eras, err := c.GetEras(ctx); err != nil || len(eras) != 2 {
+				t.Errorf("GetEras = %+v, %v", eras, err)
+			}
+			if era, err := c.GetEra(ctx, 3); err != nil || era.TimeFrame != "2017-2018" {
+				t.Errorf("GetEra(3) = %+v, %v", era, err)
+			}
+			if artist, err := c.GetArtist(ctx, 1); err != nil || artist.Name != "Juice WRLD" {
+				t.Errorf("GetArtist(1) = %+v, %v", artist, err)
+			}
+			if cats, err := c.GetCategories(ctx); err != nil || len(cats) != 2 {
+				t.Errorf("GetCategories = %+v, %v", cats, err)
+			}
+			if st, err := c.GetStats(ctx); err != nil || st.TotalSongs != 3 || st.EraStats["DRFL"] != 2 {
+				t.Errorf("GetStats = %+v, %v", st, err)
+			}
+
+			root, err := c.BrowseFiles(ctx, "", nil)
+			if err != nil || len(root.Items) != 1 || !root.Items[0].IsDir() || root.Items[0].SizeKnown() {
+				t.Errorf("BrowseFiles root = %+v, %v", root, err)
+			}
+			dir, err := c.BrowseFiles(ctx, "Compilation", nil)
+			if err != nil || len(dir.Items) != 1 || dir.Items[0].Size != 3840123 {
+				t.Errorf("BrowseFiles(Compilation) = %+v, %v", dir, err)
+			}
+			info, err := c.GetFileInfo(ctx, "Compilation/Lucid Dreams.mp3")
+			if err != nil || info.EncodingOrDefault() != EncodingBinary || info.Modified == nil {
+				t.Errorf("GetFileInfo = %+v, %v", info, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+// Package httphandler serves API audio through your own backend, so browser
+// clients can stream it without running into CORS restrictions.
+package httphandler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	juicewrld "github.com/hackinhood/juicewrld-api-wrapper-go"
+)
+
+// ProxyOptions configures NewAudioProxyHandler.
+type ProxyOptions struct {
+	// AllowedPrefixes lists the file path prefixes that may be proxied.
+	// Prefixes match whole path segments, and "/" allows every path. An
+	// empty list rejects every path, so the handler can never act as an
+	// open proxy by accident.
+	AllowedPrefixes []string
+	// AllowedOrigins lists the origins sent back in
+	// Access-Control-Allow-Origin. "*" allows any origin; an empty list
+	// sends no CORS headers.
+	AllowedOrigins []string
+	// CacheControl, if set, is sent as the Cache-Control header of
+	// successful responses.
+	CacheControl string
+}
+
+// passthroughHeaders are copied from the API response to the browser.
+var passthroughHeaders = []string{
+	"Content-Type",
+	"Content-Length",
+	"Content-Range",
+	"Accept-Ranges",
+	"Last-Modified",
+	"ETag",
+}
+
+type audioProxy struct {
+	client *juicewrld.Client
+	opts   ProxyOptions
+}
+
+// NewAudioProxyHandler returns a handler serving GET /stream?path=... by
+// forwarding the Range header to the API and streaming the body back with
+// the upstream status, so seeking in an <audio> element works. Mount it
+// under a prefix with http.StripPrefix.
+func NewAudioProxyHandler(c *juicewrld.Client, opts ProxyOptions) http.Handler {
+	return &audioProxy{client: c, opts: opts}
+}
+
+func (p *audioProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/stream" {
+		http.NotFound(w, r)
+		return
+	}
+	p.setCORS(w, r)
+	switch r.Method {
+	case http.MethodOptions:
+		w.Header().Set("Access-Control-Allow-Methods", "GET, HEAD, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Range")
+		w.WriteHeader(http.StatusNoContent)
+		return
+	case http.MethodGet, http.MethodHead:
+	default:
+		w.Header().Set("Allow", "GET, HEAD, OPTIONS")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	filePath, ok := p.allowedPath(r.URL.Query().Get("path"))
+	if !ok {
+		http.Error(w, "path not allowed", http.StatusForbidden)
+		return
+	}
+
+	open := p.client.OpenStream
+	if r.Method == http.MethodHead {
+		open = p.client.HeadStream
+	}
+	resp, err := open(r.Context(), filePath, r.Header.Get("Range"))
+	if err != nil {
+		if r.Context().Err() != nil {
+			return
+		}
+		http.Error(w, http.StatusText(upstreamStatus(err)), upstreamStatus(err))
+		return
+	}
+	defer resp.Body.Close()
+
+	for _, h := range passthroughHeaders {
+		if v := resp.Header.Get(h); v != "" {
+			w.Header().Set(h, v)
+		}
+	}
+	if p.opts.CacheControl != "" {
+		w.Header().Set("Cache-Control", p.opts.CacheControl)
+	}
+	w.WriteHeader(resp.StatusCode)
+	if r.Method == http.MethodHead {
+		return
+	}
+	// The upstream request shares r's context, so a browser disconnect
+	// aborts the copy instead of draining the rest of the file.
+	io.Copy(w, resp.Body)
+}
+
+func (p *audioProxy) setCORS(w http.ResponseWriter, r *http.Request) {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return
+	}
+	for _, o := range p.opts.AllowedOrigins {
+		if o == "*" || o == origin {
+			w.Header().Set("Access-Control-Allow-Origin", o)
+			w.Header().Set("Access-Control-Expose-Headers", "Content-Length, Content-Range, Accept-Ranges")
+			w.Header().Add("Vary", "Origin")
+			return
+		}
+	}
+}
+
+// allowedPath cleans raw and reports whether it falls under one of the
+// allowed prefixes, so "../" segments cannot escape the allowlist.
+func (p *audioProxy) allowedPath(raw string) (string, bool) {
+	if raw == "" {
+		return "", false
+	}
+	cleaned := strings.TrimPrefix(path.Clean("/"+raw), "/")
+	for _, prefix := range p.opts.AllowedPrefixes {
+		prefix = strings.Trim(path.Clean("/"+prefix), "/")
+		if prefix == "" || cleaned == prefix || strings.HasPrefix(cleaned, prefix+"/") {
+			return cleaned, true
+		}
+	}
+	return "", false
+}
+
+func upstreamStatus(err error) int {
+	var notFound *juicewrld.NotFoundError
+	if errors.As(err, &notFound) {
+		return http.StatusNotFound
+	}
+	var apiErr *juicewrld.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+		return apiErr.StatusCode
+	}
+	return http.StatusBadGateway
+}
@@ -0,0 +1,152 @@
+package httphandler
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	juicewrld "github.com/hackinhood/juicewrld-api-wrapper-go"
+)
+
+var audio = bytes.Repeat([]byte("0123456789"), 1000)
+
+// upstream is a fake API download endpoint that records the methods it
+// was called with and serves audio for "Songs/song.mp3".
+type upstream struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	methods []string
+}
+
+func newUpstream(t *testing.T, serve http.HandlerFunc) *upstream {
+	t.Helper()
+	u := &upstream{}
+	u.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u.mu.Lock()
+		u.methods = append(u.methods, r.Method)
+		u.mu.Unlock()
+		if r.URL.Path != "/juicewrld/files/download/" || r.URL.Query().Get("path") != "Songs/song.mp3" {
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"error": "not found"}`)
+			return
+		}
+		serve(w, r)
+	}))
+	t.Cleanup(u.Close)
+	return u
+}
+
+func serveAudio(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "audio/mpeg")
+	http.ServeContent(w, r, "song.mp3", time.Time{}, bytes.NewReader(audio))
+}
+
+func newProxy(u *upstream) http.Handler {
+	return NewAudioProxyHandler(juicewrld.New(u.URL), ProxyOptions{
+		AllowedPrefixes: []string{"Songs"},
+		AllowedOrigins:  []string{"https://app.example.com"},
+	})
+}
+
+func TestProxyForwardsRange(t *testing.T) {
+	u := newUpstream(t, serveAudio)
+	req := httptest.NewRequest(http.MethodGet, "/stream?path=Songs/song.mp3", nil)
+	req.Header.Set("Range", "bytes=10-19")
+	req.Header.Set("Origin", "https://app.example.com")
+	rec := httptest.NewRecorder()
+	newProxy(u).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusPartialContent {
+		t.Fatalf("status = %d, want 206", rec.Code)
+	}
+	if got := rec.Body.Bytes(); !bytes.Equal(got, audio[10:20]) {
+		t.Errorf("body = %q, want %q", got, audio[10:20])
+	}
+	want := map[string]string{
+		"Content-Range":               "bytes 10-19/10000",
+		"Content-Length":              "10",
+		"Content-Type":                "audio/mpeg",
+		"Access-Control-Allow-Origin": "https://app.example.com",
+	}
+	for h, v := range want {
+		if got := rec.Header().Get(h); got != v {
+			t.Errorf("%s = %q, want %q", h, got, v)
+		}
+	}
+}
+
+func TestProxyPassesThroughNotFound(t *testing.T) {
+	u := newUpstream(t, serveAudio)
+	rec := httptest.NewRecorder()
+	newProxy(u).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stream?path=Songs/missing.mp3", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want 404", rec.Code)
+	}
+}
+
+func TestProxyRejectsPathsOutsideAllowlist(t *testing.T) {
+	u := newUpstream(t, serveAudio)
+	for _, p := range []string{"Other/song.mp3", "Songs/../Other/song.mp3", "SongsExtra/song.mp3", ""} {
+		rec := httptest.NewRecorder()
+		newProxy(u).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stream?path="+p, nil))
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("path %q: status = %d, want 403", p, rec.Code)
+		}
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if len(u.methods) != 0 {
+		t.Errorf("rejected paths reached the API: %v", u.methods)
+	}
+}
+
+func TestProxyHeadDoesNotFetchBody(t *testing.T) {
+	u := newUpstream(t, serveAudio)
+	rec := httptest.NewRecorder()
+	newProxy(u).ServeHTTP(rec, httptest.NewRequest(http.MethodHead, "/stream?path=Songs/song.mp3", nil))
+
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Length") != "10000" || rec.Body.Len() != 0 {
+		t.Errorf("HEAD = %d, Content-Length %q, %d body bytes", rec.Code, rec.Header().Get("Content-Length"), rec.Body.Len())
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if len(u.methods) != 1 || u.methods[0] != http.MethodHead {
+		t.Errorf("upstream requests = %v, want a single HEAD", u.methods)
+	}
+}
+
+func TestProxyCancelsUpstreamOnDisconnect(t *testing.T) {
+	started, aborted := make(chan struct{}), make(chan struct{})
+	u := newUpstream(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "audio/mpeg")
+		w.Write(audio[:100])
+		w.(http.Flusher).Flush()
+		close(started)
+		<-r.Context().Done()
+		close(aborted)
+	})
+	proxy := httptest.NewServer(newProxy(u))
+	defer proxy.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, proxy.URL+"/stream?path=Songs/song.mp3", nil)
+	go func() {
+		if resp, err := http.DefaultClient.Do(req); err == nil {
+			resp.Body.Close()
+		}
+	}()
+	<-started
+	cancel()
+
+	select {
+	case <-aborted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("upstream request still open after the browser disconnected")
+	}
+}
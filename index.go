@@ -0,0 +1,131 @@
+package juicewrld
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+type SongSearchIndex struct {
+	songs      Songs
+	names      map[string][]int
+	producers  map[string][]int
+	eras       map[int][]int
+	categories map[string][]int
+}
+
+func tokenize(s string) []string {
+	return strings.FieldsFunc(foldString(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func NewSongSearchIndex(songs Songs) *SongSearchIndex {
+	idx := &SongSearchIndex{
+		songs:      songs,
+		names:      make(map[string][]int),
+		producers:  make(map[string][]int),
+		eras:       make(map[int][]int),
+		categories: make(map[string][]int),
+	}
+	for i, s := range songs {
+		addPostings(idx.names, tokenize(s.Name), i)
+		for _, p := range splitCredits(s.Producers) {
+			addPostings(idx.producers, tokenize(p), i)
+		}
+		idx.eras[s.Era.ID] = append(idx.eras[s.Era.ID], i)
+		cat := foldString(strings.TrimSpace(s.Category))
+		idx.categories[cat] = append(idx.categories[cat], i)
+	}
+	return idx
+}
+
+func addPostings(m map[string][]int, tokens []string, i int) {
+	for _, t := range tokens {
+		if list := m[t]; len(list) > 0 && list[len(list)-1] == i {
+			continue
+		}
+		m[t] = append(m[t], i)
+	}
+}
+
+func (idx *SongSearchIndex) Len() int {
+	return len(idx.songs)
+}
+
+func (idx *SongSearchIndex) Search(query string) Songs {
+	tokens := tokenize(query)
+	if len(tokens) == 0 {
+		return nil
+	}
+	var result []int
+	for i, t := range tokens {
+		postings := unionPostings(idx.names[t], idx.producers[t])
+		if i == 0 {
+			result = postings
+		} else {
+			result = intersectPostings(result, postings)
+		}
+		if len(result) == 0 {
+			return nil
+		}
+	}
+	return idx.collect(result)
+}
+
+func (idx *SongSearchIndex) FilterByCategory(cat string) Songs {
+	return idx.collect(idx.categories[foldString(strings.TrimSpace(cat))])
+}
+
+func (idx *SongSearchIndex) FilterByEra(id int) Songs {
+	return idx.collect(idx.eras[id])
+}
+
+func (idx *SongSearchIndex) collect(positions []int) Songs {
+	if len(positions) == 0 {
+		return nil
+	}
+	out := make(Songs, len(positions))
+	for i, p := range positions {
+		out[i] = idx.songs[p]
+	}
+	return out
+}
+
+func unionPostings(a, b []int) []int {
+	if len(b) == 0 {
+		return a
+	}
+	if len(a) == 0 {
+		return b
+	}
+	out := make([]int, 0, len(a)+len(b))
+	out = append(out, a...)
+	out = append(out, b...)
+	sort.Ints(out)
+	n := 0
+	for i, v := range out {
+		if i == 0 || v != out[n-1] {
+			out[n] = v
+			n++
+		}
+	}
+	return out[:n]
+}
+
+func intersectPostings(a, b []int) []int {
+	var out []int
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			out = append(out, a[i])
+			i++
+			j++
+		}
+	}
+	return out
+}
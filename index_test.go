@@ -0,0 +1,87 @@
+package juicewrld
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestSongSearchIndex(t *testing.T) {
+	idx := NewSongSearchIndex(Songs{
+		{ID: 1, Name: "Lucid Dreams", Producers: "Nick Mira", Category: "Released", Era: Era{ID: 2}},
+		{ID: 2, Name: "Lucid Dréams (Remix)", Producers: "Nick Mira, Taz Taylor", Category: "unreleased", Era: Era{ID: 2}},
+		{ID: 3, Name: "Robbery", Producers: "Nick Mira", Category: "released", Era: Era{ID: 3}},
+	})
+	tests := []struct {
+		query string
+		want  []int
+	}{
+		{"lucid dreams", []int{1, 2}},
+		{"LUCID remix", []int{2}},
+		{"nick", []int{1, 2, 3}},
+		{"taz lucid", []int{2}},
+		{"lucid robbery", nil},
+		{"  ", nil},
+	}
+	for _, tt := range tests {
+		if got := ids(idx.Search(tt.query)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+	if got := ids(idx.FilterByCategory(" Released ")); !reflect.DeepEqual(got, []int{1, 3}) {
+		t.Errorf("FilterByCategory = %v", got)
+	}
+	if got := ids(idx.FilterByEra(2)); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Errorf("FilterByEra = %v", got)
+	}
+}
+
+// indexBenchmarkSongs returns n songs drawn from a small vocabulary, so
+// every token has a long posting list.
+func indexBenchmarkSongs(n int) Songs {
+	r := rand.New(rand.NewSource(1))
+	words := []string{"lucid", "dreams", "robbery", "wishing", "well", "legends", "bandit", "righteous", "empty", "hate"}
+	producers := []string{"Nick Mira", "Taz Taylor", "Dre Moon", "Red Limits", "Charlie Handsome"}
+	categories := []string{"released", "unreleased", "unsurfaced", "recording_session"}
+	songs := make(Songs, n)
+	for i := range songs {
+		songs[i] = Song{
+			ID:        i,
+			Name:      fmt.Sprintf("%s %s %d", words[r.Intn(len(words))], words[r.Intn(len(words))], i),
+			Producers: producers[r.Intn(len(producers))] + ", " + producers[r.Intn(len(producers))],
+			Category:  categories[r.Intn(len(categories))],
+			Era:       Era{ID: r.Intn(12)},
+		}
+	}
+	return songs
+}
+
+func BenchmarkNewSongSearchIndex10k(b *testing.B) {
+	songs := indexBenchmarkSongs(10000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		NewSongSearchIndex(songs)
+	}
+}
+
+func BenchmarkSongSearchIndex10k(b *testing.B) {
+	idx := NewSongSearchIndex(indexBenchmarkSongs(10000))
+	for _, bench := range []struct {
+		name string
+		run  func() Songs
+	}{
+		{"Search/one token", func() Songs { return idx.Search("robbery") }},
+		{"Search/three tokens", func() Songs { return idx.Search("lucid dreams nick") }},
+		{"Search/unique", func() Songs { return idx.Search("9999") }},
+		{"FilterByCategory", func() Songs { return idx.FilterByCategory("released") }},
+		{"FilterByEra", func() Songs { return idx.FilterByEra(7) }},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				bench.run()
+			}
+		})
+	}
+}
@@ -0,0 +1,295 @@
+package juicewrld
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// instrumentalsDir is checked before the regular audio locations.
+const instrumentalsDir = "Instrumentals"
+
+// noInstrumental holds placeholder values the catalog uses when a song has
+// no instrumental.
+var noInstrumental = map[string]bool{"": true, "n/a": true, "na": true, "none": true, "no": true, "-": true, "unknown": true}
+
+// instrumentalFlags mark that an instrumental exists without naming it.
+var instrumentalFlags = map[string]bool{"yes": true, "available": true, "true": true}
+
+// HasInstrumental reports whether the catalog lists an instrumental for
+// the song.
+func (s Song) HasInstrumental() bool {
+	return len(s.InstrumentalTitles()) > 0
+}
+
+// InstrumentalSongs keeps the songs that have an instrumental.
+func (songs Songs) InstrumentalSongs() Songs {
+	return songs.filterInstrumental(true)
+}
+
+// WithoutInstrumentals keeps the songs that have no instrumental.
+func (songs Songs) WithoutInstrumentals() Songs {
+	return songs.filterInstrumental(false)
+}
+
+func (songs Songs) filterInstrumental(want bool) Songs {
+	var out Songs
+	for _, s := range songs {
+		if s.HasInstrumental() == want {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// GetInstrumentalSongs returns a page of songs that have an instrumental,
+// using the has_instrumental filter. When the server ignores it (the page
+// contains songs without one), the catalog is filtered client-side and
+// paged locally, without next/previous links. The client remembers such a
+// server and pages the cached catalog from then on.
+func (c *Client) GetInstrumentalSongs(ctx context.Context, page, pageSize int) (PaginatedSongsResponse, error) {
+	q := url.Values{"has_instrumental": {"true"}}
+	honoured := func(songs Songs) bool { return len(songs.WithoutInstrumentals()) == 0 }
+	return c.filteredPage(withDefaultRetryBudget(ctx), &c.instrumentalFilter, q, page, pageSize, honoured, Songs.InstrumentalSongs)
+}
+
+// InstrumentalTitles returns the titles the song's instrumentals are filed
+// under, taken from InstrumentalNames or, failing that, the Instrumentals
+// field. When the catalog only flags that an instrumental exists, the song
+// name with an " (Instrumental)" suffix is used. It returns nil when the
+// song has no known instrumental.
+func (s Song) InstrumentalTitles() []string {
+	var out []string
+	for _, n := range s.InstrumentalNames {
+		if n = strings.TrimSpace(n); !noInstrumental[strings.ToLower(n)] {
+			out = append(out, n)
+		}
+	}
+	if len(out) > 0 {
+		return out
+	}
+	if noInstrumental[strings.ToLower(strings.TrimSpace(s.Instrumentals))] {
+		return nil
+	}
+	for _, n := range splitCredits(s.Instrumentals) {
+		if key := strings.ToLower(n); !noInstrumental[key] && !instrumentalFlags[key] {
+			out = append(out, n)
+		}
+	}
+	if len(out) == 0 && s.Name != "" {
+		out = append(out, s.Name+" (Instrumental)")
+	}
+	return out
+}
+
+// resolveInstrumental finds the first instrumental file of song that
+// exists, using the same locations and format preference as playback.
+func (c *Client) resolveInstrumental(ctx context.Context, song Song) (StreamInfo, error) {
+	titles := song.InstrumentalTitles()
+	if len(titles) == 0 {
+		return StreamInfo{}, &NotFoundError{APIError{Message: fmt.Sprintf("song %d has no instrumental", song.ID)}}
+	}
+	for _, title := range titles {
+		bases := append([]string{instrumentalsDir + "/" + title}, audioPathBases("", title)...)
+		if info, ok := c.resolveAudio(ctx, bases, nil); ok {
+			info.SongID = song.ID
+			return info, nil
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return StreamInfo{}, err
+	}
+	return StreamInfo{}, &NotFoundError{APIError{Message: fmt.Sprintf("no instrumental file found for song %d", song.ID)}}
+}
+
+// DownloadSongInstrumental streams the song's instrumental to w and
+// describes the file it came from. Songs without an instrumental, or whose
+// instrumental file cannot be located, yield a *NotFoundError.
+func (c *Client) DownloadSongInstrumental(ctx context.Context, songID int, w io.Writer) (StreamInfo, error) {
+	ctx = withDefaultRetryBudget(ctx)
+	song, err := c.GetSong(ctx, songID)
+	if err != nil {
+		return StreamInfo{}, err
+	}
+	info, err := c.resolveInstrumental(ctx, song)
+	if err != nil {
+		return StreamInfo{}, err
+	}
+	resp, err := c.OpenStream(ctx, info.FilePath, "")
+	if err != nil {
+		return info, err
+	}
+	defer resp.Body.Close()
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return info, err
+	}
+	return info, nil
+}
+
+// instrumentalFolders are searched first, in order, before the whole tree.
+var instrumentalFolders = []string{instrumentalsDir, "Stems"}
+
+const (
+	minInstrumentalConfidence = 0.3
+	// instrumentalAmbiguityGap is how close the runner-up for a title must
+	// score to the best match for the pair to count as ambiguous.
+	instrumentalAmbiguityGap = 0.1
+)
+
+// InstrumentalMatch is a file that likely holds one of a song's
+// instrumentals or stems.
+type InstrumentalMatch struct {
+	FileInfo
+	// Title is the instrumental title the file was matched against.
+	Title string `json:"title"`
+	// Confidence ranges from 0 to 1; 1 means the file name equals the title.
+	Confidence float64 `json:"confidence"`
+	// Ambiguous is set on the top matches for a title when more than one
+	// file scores about the same; pick one before downloading.
+	Ambiguous bool `json:"ambiguous"`
+}
+
+// UnmarshalJSON decodes the file fields with FileInfo's rules and the match
+// fields alongside them; without it the promoted FileInfo.UnmarshalJSON
+// would drop Title, Confidence and Ambiguous.
+func (m *InstrumentalMatch) UnmarshalJSON(data []byte) error {
+	var match struct {
+		Title      string  `json:"title"`
+		Confidence float64 `json:"confidence"`
+		Ambiguous  bool    `json:"ambiguous"`
+	}
+	if err := json.Unmarshal(data, &match); err != nil {
+		return err
+	}
+	if err := m.FileInfo.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	m.Title, m.Confidence, m.Ambiguous = match.Title, match.Confidence, match.Ambiguous
+	return nil
+}
+
+// GetSongInstrumentals searches the files API for the song's instrumentals
+// and stems, preferring the Instrumentals and Stems folders, and returns the
+// candidates best first. Songs without instrumentals yield an empty slice.
+func (c *Client) GetSongInstrumentals(ctx context.Context, songID int) ([]InstrumentalMatch, error) {
+	ctx = withDefaultRetryBudget(ctx)
+	song, err := c.GetSong(ctx, songID)
+	if err != nil {
+		return nil, err
+	}
+	out := []InstrumentalMatch{}
+	seen := map[string]bool{}
+	for _, title := range song.InstrumentalTitles() {
+		matches, err := c.searchInstrumental(ctx, title)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range matches {
+			if !seen[m.Path] {
+				seen[m.Path] = true
+				out = append(out, m)
+			}
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Confidence > out[j].Confidence })
+	return out, nil
+}
+
+func (c *Client) searchInstrumental(ctx context.Context, title string) ([]InstrumentalMatch, error) {
+	var matches []InstrumentalMatch
+	for _, dir := range append(instrumentalFolders, "") {
+		listing, err := c.BrowseFiles(ctx, dir, &title)
+		var notFound *NotFoundError
+		if errors.As(err, &notFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range listing.Items {
+			if !IsPlayable(f) {
+				continue
+			}
+			conf := instrumentalConfidence(title, f)
+			if dir != "" {
+				conf = min(conf+0.1, 1)
+			}
+			if conf >= minInstrumentalConfidence {
+				matches = append(matches, InstrumentalMatch{FileInfo: f, Title: title, Confidence: conf})
+			}
+		}
+		if len(matches) > 0 {
+			break
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Confidence > matches[j].Confidence })
+	for i := 1; i < len(matches) && matches[0].Confidence-matches[i].Confidence < instrumentalAmbiguityGap; i++ {
+		matches[0].Ambiguous, matches[i].Ambiguous = true, true
+	}
+	return matches, nil
+}
+
+// instrumentalConfidence scores how well a file name matches title: 1 for
+// an exact (folded) match, 0.7 when the name contains the title, otherwise
+// half the share of title words found in the name.
+func instrumentalConfidence(title string, f FileInfo) float64 {
+	name := strings.TrimSuffix(f.Name, path.Ext(f.Name))
+	ft, fn := foldString(strings.TrimSpace(title)), foldString(name)
+	switch {
+	case ft == fn:
+		return 1
+	case strings.Contains(fn, ft):
+		return 0.7
+	}
+	words := tokenize(title)
+	if len(words) == 0 {
+		return 0
+	}
+	have := map[string]bool{}
+	for _, w := range tokenize(name) {
+		have[w] = true
+	}
+	hits := 0
+	for _, w := range words {
+		if have[w] {
+			hits++
+		}
+	}
+	return 0.5 * float64(hits) / float64(len(words))
+}
+
+// DownloadSongInstrumentals downloads the best match for each of the song's
+// instrumental titles into destDir using DownloadFiles. If any title has
+// ambiguous matches nothing is downloaded and an
+// *AmbiguousInstrumentalError lists the candidates.
+func (c *Client) DownloadSongInstrumentals(ctx context.Context, songID int, destDir string) ([]DownloadResult, error) {
+	matches, err := c.GetSongInstrumentals(ctx, songID)
+	if err != nil {
+		return nil, err
+	}
+	var tasks []DownloadTask
+	var ambiguous []InstrumentalMatch
+	picked := map[string]bool{}
+	for _, m := range matches {
+		if m.Ambiguous {
+			ambiguous = append(ambiguous, m)
+			continue
+		}
+		if picked[m.Title] {
+			continue
+		}
+		picked[m.Title] = true
+		tasks = append(tasks, DownloadTask{RemotePath: m.Path, LocalPath: filepath.Join(destDir, filepath.Base(m.Name))})
+	}
+	if len(ambiguous) > 0 {
+		return nil, &AmbiguousInstrumentalError{SongID: songID, Candidates: ambiguous}
+	}
+	return c.DownloadFiles(ctx, tasks, c.concurrencyLimit())
+}
@@ -0,0 +1,74 @@
+package juicewrld
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestInstrumentalMatchJSONRoundTrip(t *testing.T) {
+	m := InstrumentalMatch{
+		FileInfo:   FileInfo{Name: "Song (Instrumental).mp3", Type: "file", Path: "Instrumentals/Song (Instrumental).mp3", Size: 42},
+		Title:      "Song (Instrumental)",
+		Confidence: 0.9,
+		Ambiguous:  true,
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got InstrumentalMatch
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, m) {
+		t.Errorf("round trip = %+v, want %+v", got, m)
+	}
+
+	if err := json.Unmarshal([]byte(`{"name":"a.mp3","size":null,"title":"A","confidence":0.5}`), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Size != -1 || got.Title != "A" || got.Confidence != 0.5 || got.Ambiguous {
+		t.Errorf("decoded %+v, want FileInfo's size rules and the match fields", got)
+	}
+}
+
+func TestGetInstrumentalSongsRemembersIgnoredFilter(t *testing.T) {
+	songs := []map[string]interface{}{
+		{"id": 1, "name": "One", "instrumentals": "yes"},
+		{"id": 2, "name": "Two", "instrumentals": "n/a"},
+		{"id": 3, "name": "Three", "instrumentals": "yes"},
+		{"id": 4, "name": "Four", "instrumentals": "yes"},
+	}
+	var api *fakeAPI
+	api = newFakeAPI(t, map[string]http.HandlerFunc{
+		// Pages of two, whatever the filter or page_size.
+		"/juicewrld/songs/": func(w http.ResponseWriter, r *http.Request) {
+			body := map[string]interface{}{"count": len(songs), "results": songs[:2]}
+			if r.URL.Query().Get("page") == "2" {
+				body["results"] = songs[2:]
+			} else {
+				body["next"] = fmt.Sprintf("%s/juicewrld/songs/?page=2", api.URL)
+			}
+			writeJSON(w, http.StatusOK, body)
+		},
+	})
+	ctx := context.Background()
+	c := api.client()
+
+	for page, want := range [][]int{{1, 3}, {4}} {
+		out, err := c.GetInstrumentalSongs(ctx, page+1, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(ids(out.Results), want) || out.Count != 3 {
+			t.Errorf("page %d = %v count %d, want %v count 3", page+1, ids(out.Results), out.Count, want)
+		}
+	}
+	if n := api.hitCount("/juicewrld/songs/"); n != 3 {
+		t.Errorf("%d song requests, want one filtered page and a two-page catalog scan", n)
+	}
+}
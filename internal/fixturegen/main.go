@@ -0,0 +1,123 @@
+// Command fixturegen captures representative API responses as fixtures for
+// offline tests:
+//
+//	go run ./internal/fixturegen -out testdata/mirror
+//
+// Responses are stored as the server sent them, in the layout
+// WithOfflineSource reads (see Client.ExportStaticMirror), so fields the
+// client does not decode are kept. Volatile fields are replaced with fixed
+// values, the real host is swapped for fixtureBaseURL and object keys are
+// sorted, so regenerating against an unchanged catalog produces no diff.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"strings"
+	"time"
+
+	juicewrld "github.com/hackinhood/juicewrld-api-wrapper-go"
+)
+
+// fixtureBaseURL replaces the real host in pagination links and stream URLs.
+const fixtureBaseURL = "http://fixtures.invalid"
+
+// volatileKeys are overwritten with fixedTime or emptied when sanitizing.
+var volatileKeys = map[string]bool{
+	"created":      true,
+	"modified":     true,
+	"timestamp":    true,
+	"generated_at": true,
+	"query_time":   true,
+	"request_id":   true,
+}
+
+const fixedTime = "2000-01-01T00:00:00Z"
+
+func main() {
+	var (
+		baseURL    = flag.String("base", "https://juicewrldapi.com", "API base URL to capture from")
+		out        = flag.String("out", "testdata/mirror", "directory to write fixtures to")
+		filesDir   = flag.String("files", "", "root of the files subtree to capture")
+		filesDepth = flag.Int("files-depth", 2, "directory levels of the files subtree to capture")
+		pageSize   = flag.Int("page-size", 2, "items per captured song page")
+		songPages  = flag.Int("song-pages", 2, "song pages to capture")
+		details    = flag.Int("details", 1, "detail records to capture per listing")
+		timeout    = flag.Duration("timeout", 2*time.Minute, "overall capture timeout")
+	)
+	flag.Parse()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	g := &generator{baseURL: strings.TrimRight(*baseURL, "/")}
+	client := juicewrld.New(*baseURL, juicewrld.WithRetry(3, time.Second))
+	err := client.ExportStaticMirror(ctx, *out, &juicewrld.MirrorOptions{
+		PageSize:     *pageSize,
+		MaxSongPages: *songPages,
+		DetailLimit:  *details,
+		Files:        true,
+		FilesRoot:    *filesDir,
+		FilesDepth:   *filesDepth,
+		FileInfo:     true,
+		Transform:    g.transform,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+type generator struct {
+	baseURL string
+}
+
+// transform sanitizes a raw response body and re-indents it with sorted
+// keys. Numbers are kept verbatim and HTML characters are left unescaped,
+// so only the sanitized values differ from what the server sent.
+func (g *generator) transform(name string, body []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var generic interface{}
+	if err := dec.Decode(&generic); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(g.sanitize("", generic)); err != nil {
+		return nil, err
+	}
+	log.Printf("captured %s", name)
+	return buf.Bytes(), nil
+}
+
+func (g *generator) sanitize(key string, v interface{}) interface{} {
+	if volatileKeys[key] {
+		if s, ok := v.(string); ok && s != "" {
+			if _, err := time.Parse(time.RFC3339, s); err == nil {
+				return fixedTime
+			}
+			return ""
+		}
+		return v
+	}
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, child := range t {
+			t[k] = g.sanitize(k, child)
+		}
+		return t
+	case []interface{}:
+		for i, child := range t {
+			t[i] = g.sanitize("", child)
+		}
+		return t
+	case string:
+		return strings.ReplaceAll(t, g.baseURL, fixtureBaseURL)
+	}
+	return v
+}
@@ -0,0 +1,28 @@
+package main
+
+import "testing"
+
+func TestTransformSanitizesRawBody(t *testing.T) {
+	g := &generator{baseURL: "https://api.example"}
+	body := []byte(`{"zeta":1,"id":12345678901234567890,"next":"https://api.example/juicewrld/songs/?page=2",` +
+		`"modified":"2024-05-01T10:00:00Z","request_id":"abc","notes":"a <b> & c","unknown":{"kept":true}}`)
+	got, err := g.transform("juicewrld/songs/index.json", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+  "id": 12345678901234567890,
+  "modified": "2000-01-01T00:00:00Z",
+  "next": "http://fixtures.invalid/juicewrld/songs/?page=2",
+  "notes": "a <b> & c",
+  "request_id": "",
+  "unknown": {
+    "kept": true
+  },
+  "zeta": 1
+}
+`
+	if string(got) != want {
+		t.Errorf("transform =\n%s\nwant\n%s", got, want)
+	}
+}
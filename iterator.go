@@ -0,0 +1,101 @@
+package juicewrld
+
+import "context"
+
+type Iterator[T any] struct {
+	ctx   context.Context
+	fetch func(ctx context.Context) ([]T, bool, error)
+	buf   []T
+	cur   T
+	done  bool
+	err   error
+}
+
+func newIterator[T any](ctx context.Context, fetch func(ctx context.Context) ([]T, bool, error)) *Iterator[T] {
+	return &Iterator[T]{ctx: ctx, fetch: fetch}
+}
+
+func (it *Iterator[T]) Next() bool {
+	for len(it.buf) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		if err := it.ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+		items, ok, err := it.fetch(it.ctx)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		// A page can come back empty when the client filtered out all
+		// of it, so only the fetcher decides where the data ends.
+		if !ok {
+			it.done = true
+			return false
+		}
+		it.buf = items
+	}
+	it.cur, it.buf = it.buf[0], it.buf[1:]
+	return true
+}
+
+func (it *Iterator[T]) Item() T {
+	return it.cur
+}
+
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+func (it *Iterator[T]) Collect() ([]T, error) {
+	var out []T
+	for it.Next() {
+		out = append(out, it.Item())
+	}
+	return out, it.Err()
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// IterateSongs yields the songs matching filter page by page. With several
+// eras, each era is paged in turn and songs already yielded are skipped.
+func (c *Client) IterateSongs(ctx context.Context, filter *SongFilter) *Iterator[Song] {
+	filters := filter.perEra()
+	pages := make([]*Paginator[Song], len(filters))
+	for i, f := range filters {
+		pages[i] = NewPaginator[Song](c, "/juicewrld/songs/", c.songFilterQuery(f))
+	}
+	seen := map[int]bool{}
+	return newIterator(withDefaultRetryBudget(ctx), func(ctx context.Context) ([]Song, bool, error) {
+		for len(pages) > 0 {
+			songs, ok, err := pages[0].Next(ctx)
+			if err != nil {
+				return nil, false, err
+			}
+			if !ok {
+				pages = pages[1:]
+				continue
+			}
+			songs = c.exclude(filter, songs)
+			if len(filters) == 1 {
+				return songs, true, nil
+			}
+			var out Songs
+			for _, s := range songs {
+				if !seen[s.ID] {
+					seen[s.ID] = true
+					out = append(out, s)
+				}
+			}
+			return out, true, nil
+		}
+		return nil, false, nil
+	})
+}
@@ -0,0 +1,247 @@
+package juicewrld
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const journalBufferSize = 1024
+
+type JournalEntry struct {
+	Time       time.Time `json:"time"`
+	Method     string    `json:"method"`
+	URL        string    `json:"url"`
+	Status     int       `json:"status"`
+	DurationMS int64     `json:"duration_ms"`
+	Bytes      int64     `json:"bytes"`
+	Attempt    int       `json:"attempt"`
+	Error      string    `json:"error,omitempty"`
+}
+
+type JournalSummary struct {
+	Total      int            `json:"total"`
+	Errors     int            `json:"errors"`
+	ByStatus   map[int]int    `json:"by_status"`
+	ByEndpoint map[string]int `json:"by_endpoint"`
+}
+
+// WithJournal appends one JSON line per HTTP request to w. Entries are
+// written by a single goroutine through a bounded buffer; when the buffer is
+// full, entries are dropped rather than delaying requests. Call Close to
+// flush pending entries.
+func WithJournal(w io.Writer) Option {
+	return func(c *Client) {
+		if w != nil {
+			c.journal = newJournal(w)
+		}
+	}
+}
+
+type journal struct {
+	w       io.Writer
+	entries chan JournalEntry
+	done    chan struct{}
+	mu      sync.RWMutex
+	closed  bool
+	dropped atomic.Int64
+}
+
+func newJournal(w io.Writer) *journal {
+	j := &journal{
+		w:       w,
+		entries: make(chan JournalEntry, journalBufferSize),
+		done:    make(chan struct{}),
+	}
+	go j.run()
+	return j
+}
+
+func (j *journal) run() {
+	defer close(j.done)
+	for e := range j.entries {
+		line, err := json.Marshal(e)
+		if err != nil {
+			continue
+		}
+		j.w.Write(append(line, '\n'))
+		if f, ok := j.w.(interface{ Flush() error }); ok {
+			f.Flush()
+		}
+	}
+}
+
+func (j *journal) record(e JournalEntry) {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	if j.closed {
+		j.dropped.Add(1)
+		return
+	}
+	select {
+	case j.entries <- e:
+	default:
+		j.dropped.Add(1)
+	}
+}
+
+func (j *journal) close() {
+	j.mu.Lock()
+	if !j.closed {
+		j.closed = true
+		close(j.entries)
+	}
+	j.mu.Unlock()
+	<-j.done
+}
+
+func (c *Client) JournalDropped() int64 {
+	if c.journal == nil {
+		return 0
+	}
+	return c.journal.dropped.Load()
+}
+
+type attemptKey struct{}
+
+func withAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, attemptKey{}, attempt)
+}
+
+func attemptFrom(ctx context.Context) int {
+	n, _ := ctx.Value(attemptKey{}).(int)
+	return n
+}
+
+type journalTransport struct {
+	base    http.RoundTripper
+	journal *journal
+	clock   Clock
+}
+
+func (t *journalTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := t.clock.Now()
+	entry := JournalEntry{
+		Time:    start.UTC(),
+		Method:  req.Method,
+		URL:     redactURL(req.URL),
+		Attempt: attemptFrom(req.Context()),
+	}
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		entry.DurationMS = t.clock.Now().Sub(start).Milliseconds()
+		entry.Error = err.Error()
+		t.journal.record(entry)
+		return resp, err
+	}
+	entry.Status = resp.StatusCode
+	resp.Body = &journalBody{ReadCloser: resp.Body, entry: entry, start: start, journal: t.journal, clock: t.clock}
+	return resp, nil
+}
+
+func (t *journalTransport) CloseIdleConnections() {
+	if ci, ok := t.base.(interface{ CloseIdleConnections() }); ok {
+		ci.CloseIdleConnections()
+	}
+}
+
+type journalBody struct {
+	io.ReadCloser
+	entry   JournalEntry
+	start   time.Time
+	journal *journal
+	clock   Clock
+	once    sync.Once
+	readErr error
+}
+
+func (b *journalBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.entry.Bytes += int64(n)
+	if err != nil && err != io.EOF {
+		b.readErr = err
+	}
+	return n, err
+}
+
+func (b *journalBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() {
+		b.entry.DurationMS = b.clock.Now().Sub(b.start).Milliseconds()
+		if b.readErr != nil {
+			b.entry.Error = b.readErr.Error()
+		}
+		b.journal.record(b.entry)
+	})
+	return err
+}
+
+var redactedParams = []string{"token", "access_token", "api_key", "apikey", "key", "signature", "sig", "password", "secret"}
+
+func redactURL(u *url.URL) string {
+	cp := *u
+	if cp.User != nil {
+		cp.User = url.User("REDACTED")
+	}
+	if cp.RawQuery != "" {
+		q := cp.Query()
+		for k := range q {
+			for _, p := range redactedParams {
+				if strings.EqualFold(k, p) {
+					q.Set(k, "REDACTED")
+				}
+			}
+		}
+		cp.RawQuery = q.Encode()
+	}
+	return cp.String()
+}
+
+func ReadJournal(r io.Reader) ([]JournalEntry, error) {
+	var out []JournalEntry
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64<<10), 1<<20)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		var e JournalEntry
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			return out, err
+		}
+		out = append(out, e)
+	}
+	return out, sc.Err()
+}
+
+func SummarizeJournal(entries []JournalEntry) JournalSummary {
+	s := JournalSummary{
+		ByStatus:   make(map[int]int),
+		ByEndpoint: make(map[string]int),
+	}
+	for _, e := range entries {
+		s.Total++
+		if e.Error != "" || e.Status >= 400 {
+			s.Errors++
+		}
+		s.ByStatus[e.Status]++
+		endpoint := e.URL
+		if u, err := url.Parse(e.URL); err == nil {
+			endpoint = u.Path
+		}
+		s.ByEndpoint[endpoint]++
+	}
+	return s
+}
@@ -0,0 +1,45 @@
+package juicewrld
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestJournalForwardsCloseIdleConnections(t *testing.T) {
+	var closed atomic.Int32
+	c := New("http://example.invalid",
+		WithHTTPClient(&http.Client{Transport: &closeCountingTransport{closed: &closed}}),
+		WithJournal(io.Discard))
+	defer c.Close()
+	c.CloseIdleConnections()
+	if closed.Load() != 1 {
+		t.Errorf("CloseIdleConnections reached the wrapped transport %d times, want 1", closed.Load())
+	}
+}
+
+func TestJournalTimesRequestsOnClientClock(t *testing.T) {
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"/juicewrld/songs/7/": jsonHandler(map[string]interface{}{"id": 7, "name": "Song"}),
+	})
+	var buf bytes.Buffer
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := api.client(WithJournal(&buf), WithClock(&steppingClock{at: start, step: 2 * time.Second}))
+	if _, err := c.GetSong(context.Background(), 7); err != nil {
+		t.Fatal(err)
+	}
+	c.Close()
+
+	var entry JournalEntry
+	if err := json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &entry); err != nil {
+		t.Fatalf("journal = %q: %v", buf.String(), err)
+	}
+	if !entry.Time.Equal(start.Add(2*time.Second)) || entry.DurationMS != 2000 {
+		t.Errorf("entry time %v, duration %dms; want %v and 2000ms on the injected clock", entry.Time, entry.DurationMS, start.Add(2*time.Second))
+	}
+}
@@ -0,0 +1,61 @@
+package juicewrld
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+func (c *Client) ExportSongsJSONL(ctx context.Context, filter *SongFilter, w io.Writer) (int, error) {
+	ctx = withDefaultRetryBudget(ctx)
+	pages := NewPaginator[Song](c, "/juicewrld/songs/", c.songFilterQuery(filter))
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	written := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return written, err
+		}
+		songs, ok, err := pages.Next(ctx)
+		if err != nil {
+			return written, err
+		}
+		if !ok {
+			return written, nil
+		}
+		for _, s := range c.exclude(filter, songs) {
+			if err := enc.Encode(s); err != nil {
+				return written, err
+			}
+			written++
+		}
+		if err := bw.Flush(); err != nil {
+			return written, err
+		}
+		if f, ok := w.(interface{ Flush() error }); ok {
+			if err := f.Flush(); err != nil {
+				return written, err
+			}
+		}
+	}
+}
+
+func ImportSongsJSONL(r io.Reader) (Songs, error) {
+	var out Songs
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64<<10), 16<<20)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		var s Song
+		if err := json.Unmarshal([]byte(line), &s); err != nil {
+			return out, err
+		}
+		out = append(out, s)
+	}
+	return out, sc.Err()
+}
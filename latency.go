@@ -0,0 +1,128 @@
+package juicewrld
+
+import (
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+const defaultLatencyBufferSize = 256
+
+// LatencyStats summarises the most recent request durations recorded with
+// WithLatencyTracking. Durations run from sending a request to receiving
+// its response headers; each retry attempt counts as its own request.
+type LatencyStats struct {
+	Count int
+	Min   time.Duration
+	Max   time.Duration
+	P50   time.Duration
+	P95   time.Duration
+}
+
+// WithLatencyTracking records request durations in a ring buffer of the
+// last 256 requests for Latencies. Tracking is off by default.
+func WithLatencyTracking() Option {
+	return func(c *Client) {
+		if c.latency == nil {
+			c.latency = &latencyRing{}
+		}
+	}
+}
+
+// WithLatencyBufferSize enables latency tracking with a ring buffer of the
+// last n requests.
+func WithLatencyBufferSize(n int) Option {
+	return func(c *Client) {
+		c.latency = &latencyRing{size: n}
+	}
+}
+
+// Latencies returns statistics over the buffered request durations, or
+// zero stats when latency tracking is off or nothing has been recorded.
+func (c *Client) Latencies() LatencyStats {
+	if c.latency == nil {
+		return LatencyStats{}
+	}
+	return c.latency.stats()
+}
+
+type latencyRing struct {
+	mu      sync.Mutex
+	size    int
+	samples []time.Duration
+	next    int
+}
+
+func (r *latencyRing) record(d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	size := r.size
+	if size <= 0 {
+		size = defaultLatencyBufferSize
+	}
+	if len(r.samples) < size {
+		r.samples = append(r.samples, d)
+		return
+	}
+	r.samples[r.next] = d
+	r.next = (r.next + 1) % size
+}
+
+func (r *latencyRing) stats() LatencyStats {
+	r.mu.Lock()
+	sorted := slices.Clone(r.samples)
+	r.mu.Unlock()
+	if len(sorted) == 0 {
+		return LatencyStats{}
+	}
+	slices.Sort(sorted)
+	return LatencyStats{
+		Count: len(sorted),
+		Min:   sorted[0],
+		Max:   sorted[len(sorted)-1],
+		P50:   percentile(sorted, 50),
+		P95:   percentile(sorted, 95),
+	}
+}
+
+// percentile uses the nearest-rank method on an ascending slice.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func (c *Client) installLatencyTracking() {
+	if c.latency == nil {
+		return
+	}
+	hc := *c.HTTPClient
+	hc.Transport = &latencyTransport{base: hc.Transport, ring: c.latency, clock: c.clock}
+	c.HTTPClient = &hc
+}
+
+type latencyTransport struct {
+	base  http.RoundTripper
+	ring  *latencyRing
+	clock Clock
+}
+
+func (t *latencyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	start := t.clock.Now()
+	resp, err := base.RoundTrip(req)
+	t.ring.record(t.clock.Now().Sub(start))
+	return resp, err
+}
+
+func (t *latencyTransport) CloseIdleConnections() {
+	if ci, ok := t.base.(interface{ CloseIdleConnections() }); ok {
+		ci.CloseIdleConnections()
+	}
+}
@@ -0,0 +1,50 @@
+package juicewrld
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// steppingClock advances by step on every Now call.
+type steppingClock struct {
+	realClock
+	mu   sync.Mutex
+	at   time.Time
+	step time.Duration
+}
+
+func (c *steppingClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.at = c.at.Add(c.step)
+	return c.at
+}
+
+func TestLatencyTrackingUsesClientClock(t *testing.T) {
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"/juicewrld/songs/7/": jsonHandler(map[string]interface{}{"id": 7, "name": "Song"}),
+	})
+	c := api.client(WithLatencyTracking(), WithClock(&steppingClock{step: 3 * time.Second}))
+	if _, err := c.GetSong(context.Background(), 7); err != nil {
+		t.Fatal(err)
+	}
+	st := c.Latencies()
+	if st.Count != 1 || st.Max != 3*time.Second {
+		t.Errorf("Latencies = %+v, want one request of 3s on the injected clock", st)
+	}
+}
+
+func TestLatencyTrackingForwardsCloseIdleConnections(t *testing.T) {
+	var closed atomic.Int32
+	c := New("http://example.invalid",
+		WithHTTPClient(&http.Client{Transport: &closeCountingTransport{closed: &closed}}),
+		WithLatencyTracking())
+	c.CloseIdleConnections()
+	if closed.Load() != 1 {
+		t.Errorf("CloseIdleConnections reached the wrapped transport %d times, want 1", closed.Load())
+	}
+}
@@ -0,0 +1,283 @@
+package juicewrld
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// MirrorOptions selects what ExportStaticMirror copies. The zero value
+// copies every song page, the album, era, artist, category and stats
+// listings, and a detail record for every item listed, but no files.
+type MirrorOptions struct {
+	// PageSize is sent as page_size on song pages; zero leaves the
+	// server's default.
+	PageSize int
+	// MaxSongPages stops after that many song pages; zero copies them
+	// all. The last page copied has its next link cleared so iterators
+	// over the mirror end there.
+	MaxSongPages int
+	// DetailLimit caps the per-item records copied for each listing:
+	// detail records for songs, albums, eras and artists, and info
+	// records and downloads for the files of each directory. Zero copies
+	// one for every item, a negative value none.
+	DetailLimit int
+
+	// Files copies the file tree under FilesRoot ("" for the top), down
+	// to FilesDepth levels below it (zero for no limit).
+	Files      bool
+	FilesRoot  string
+	FilesDepth int
+	// FileInfo copies the info record of every file listed.
+	FileInfo bool
+	// Downloads copies the content of every file listed.
+	Downloads bool
+
+	// Transform, when set, rewrites each JSON body before it is written,
+	// for example to strip volatile fields. name is the file's
+	// slash-separated path inside the mirror directory.
+	Transform func(name string, body []byte) ([]byte, error)
+}
+
+// ExportStaticMirror copies the catalog into dir in the layout
+// WithOfflineSource reads, so a client pointed at dir serves the same
+// requests without the network. JSON bodies are stored as the server sent
+// them, including fields this package does not decode. Existing files are
+// overwritten; nothing else in dir is touched.
+func (c *Client) ExportStaticMirror(ctx context.Context, dir string, opts *MirrorOptions) error {
+	if opts == nil {
+		opts = &MirrorOptions{}
+	}
+	ctx = withDefaultRetryBudget(ctx)
+	m := &mirror{client: c, dir: dir, opts: opts}
+
+	if err := m.songs(ctx); err != nil {
+		return err
+	}
+	for _, coll := range []string{"albums", "eras", "artists"} {
+		if err := m.collection(ctx, coll); err != nil {
+			return err
+		}
+	}
+	for _, p := range []string{"/juicewrld/categories/", "/juicewrld/stats/"} {
+		if _, err := m.copyJSON(ctx, p, nil); err != nil {
+			return err
+		}
+	}
+	if opts.Files {
+		return m.files(ctx, opts.FilesRoot, 0)
+	}
+	return nil
+}
+
+type mirror struct {
+	client *Client
+	dir    string
+	opts   *MirrorOptions
+}
+
+// detailCount is how many of n listed items get a per-item record.
+func (m *mirror) detailCount(n int) int {
+	switch {
+	case m.opts.DetailLimit < 0:
+		return 0
+	case m.opts.DetailLimit > 0:
+		return min(n, m.opts.DetailLimit)
+	}
+	return n
+}
+
+func (m *mirror) songs(ctx context.Context) error {
+	details := 0
+	for page := 1; ; page++ {
+		q := url.Values{}
+		if page > 1 {
+			q.Set("page", strconv.Itoa(page))
+		}
+		if m.opts.PageSize > 0 {
+			q.Set("page_size", strconv.Itoa(m.opts.PageSize))
+		}
+		last := m.opts.MaxSongPages > 0 && page >= m.opts.MaxSongPages
+		raw, err := m.fetch(ctx, "/juicewrld/songs/", q)
+		if err != nil {
+			return fmt.Errorf("mirror songs page %d: %w", page, err)
+		}
+		var out PaginatedSongsResponse
+		if err := m.client.decodeList(raw, &out); err != nil {
+			return fmt.Errorf("mirror songs page %d: %w", page, err)
+		}
+		if last && out.Next != nil {
+			if raw, err = m.clearNext(raw); err != nil {
+				return err
+			}
+		}
+		if err := m.write("/juicewrld/songs/", q, raw); err != nil {
+			return err
+		}
+		// The detail limit covers the whole listing, not each page.
+		n := m.detailCount(details+len(out.Results)) - details
+		for _, s := range out.Results[:max(n, 0)] {
+			if _, err := m.copyJSON(ctx, fmt.Sprintf("/juicewrld/songs/%d/", s.ID), nil); err != nil {
+				return err
+			}
+		}
+		details += len(out.Results)
+		if last || out.Next == nil || len(out.Results) == 0 {
+			return nil
+		}
+	}
+}
+
+// collection copies /juicewrld/<name>/ and the detail record of each item.
+func (m *mirror) collection(ctx context.Context, name string) error {
+	raw, err := m.copyJSON(ctx, "/juicewrld/"+name+"/", nil)
+	if err != nil {
+		return err
+	}
+	var list struct {
+		Results []struct {
+			ID int `json:"id"`
+		} `json:"results"`
+	}
+	if err := m.client.decodeList(raw, &list); err != nil {
+		return fmt.Errorf("mirror %s: %w", name, err)
+	}
+	for _, item := range list.Results[:m.detailCount(len(list.Results))] {
+		if _, err := m.copyJSON(ctx, fmt.Sprintf("/juicewrld/%s/%d/", name, item.ID), nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *mirror) files(ctx context.Context, dir string, depth int) error {
+	q := url.Values{}
+	if dir != "" {
+		q.Set("path", m.client.normalizePath(dir))
+	}
+	raw, err := m.copyJSON(ctx, "/juicewrld/files/browse/", q)
+	if err != nil {
+		return err
+	}
+	var listing DirectoryInfo
+	if err := m.client.unmarshal(raw, &listing); err != nil {
+		return fmt.Errorf("mirror browse %q: %w", dir, err)
+	}
+	var files []string
+	for _, item := range listing.Items {
+		p := item.Path
+		if p == "" {
+			p = listing.Join(item.Name)
+		}
+		if !item.IsDir() {
+			files = append(files, p)
+			continue
+		}
+		if m.opts.FilesDepth == 0 || depth+1 < m.opts.FilesDepth {
+			if err := m.files(ctx, p, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+	for _, p := range files[:m.detailCount(len(files))] {
+		if m.opts.FileInfo {
+			if _, err := m.copyJSON(ctx, "/juicewrld/files/info/", url.Values{"path": {m.client.normalizePath(p)}}); err != nil {
+				return err
+			}
+		}
+		if m.opts.Downloads {
+			if err := m.download(ctx, p); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// copyJSON fetches a JSON endpoint and stores its body, returning the body
+// as fetched.
+func (m *mirror) copyJSON(ctx context.Context, p string, q url.Values) (json.RawMessage, error) {
+	raw, err := m.fetch(ctx, p, q)
+	if err != nil {
+		return nil, fmt.Errorf("mirror %s: %w", p, err)
+	}
+	return raw, m.write(p, q, raw)
+}
+
+func (m *mirror) fetch(ctx context.Context, p string, q url.Values) (json.RawMessage, error) {
+	var raw json.RawMessage
+	err := m.client.get(ctx, p, q, &raw)
+	return raw, err
+}
+
+// clearNext sets the page's next link to null.
+func (m *mirror) clearNext(raw json.RawMessage) (json.RawMessage, error) {
+	key := m.client.paginationKeys().Next
+	if key == "" {
+		key = DefaultPaginationKeys.Next
+	}
+	var page map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &page); err != nil {
+		return nil, err
+	}
+	page[key] = json.RawMessage("null")
+	return json.Marshal(page)
+}
+
+func (m *mirror) download(ctx context.Context, p string) error {
+	name, _, ok := offlineFile("juicewrld/files/download", url.Values{"path": {p}})
+	if !ok {
+		return nil
+	}
+	resp, err := m.client.OpenStream(ctx, p, "")
+	if err != nil {
+		return fmt.Errorf("mirror download %q: %w", p, err)
+	}
+	defer resp.Body.Close()
+	dst, err := m.create(name)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, resp.Body); err != nil {
+		dst.Close()
+		return fmt.Errorf("mirror download %q: %w", p, err)
+	}
+	return dst.Close()
+}
+
+// write stores body under the offline name for p and q.
+func (m *mirror) write(p string, q url.Values, body []byte) error {
+	name, _, ok := offlineFile(strings.Trim(p, "/"), q)
+	if !ok {
+		return fmt.Errorf("mirror %s: no offline file for query %q", p, q.Encode())
+	}
+	if m.opts.Transform != nil {
+		var err error
+		if body, err = m.opts.Transform(name, body); err != nil {
+			return fmt.Errorf("mirror %s: %w", name, err)
+		}
+	}
+	f, err := m.create(name)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(body); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (m *mirror) create(name string) (*os.File, error) {
+	full := filepath.Join(m.dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+		return nil, err
+	}
+	return os.Create(full)
+}
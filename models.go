@@ -2,6 +2,9 @@ package juicewrld
 
 import (
 	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -40,6 +43,38 @@ func (ft FlexibleTime) MarshalJSON() ([]byte, error) {
 	return json.Marshal(ft.Time.Format(time.RFC3339))
 }
 
+// StringOrSlice decodes fields that some records send as a single string
+// and others as an array of strings. An empty string or null decodes to nil.
+type StringOrSlice []string
+
+func (s *StringOrSlice) UnmarshalJSON(data []byte) error {
+	var one *string
+	if err := json.Unmarshal(data, &one); err == nil {
+		*s = nil
+		if one != nil && *one != "" {
+			*s = StringOrSlice{*one}
+		}
+		return nil
+	}
+	var many []*string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	out := make(StringOrSlice, 0, len(many))
+	for _, v := range many {
+		if v != nil {
+			out = append(out, *v)
+		}
+	}
+	*s = out
+	return nil
+}
+
+// String joins the values with ", " for display.
+func (s StringOrSlice) String() string {
+	return strings.Join(s, ", ")
+}
+
 type Artist struct {
 	ID   int    `json:"id"`
 	Name string `json:"name"`
@@ -53,6 +88,10 @@ type Album struct {
 	Artist      Artist       `json:"artist"`
 	ReleaseDate FlexibleTime `json:"release_date"`
 	Description string       `json:"description"`
+	// CoverArtPath and CoverArtURL are set only by deployments that expose
+	// album artwork directly; see GetAlbumCoverArt.
+	CoverArtPath string `json:"cover_art_path,omitempty"`
+	CoverArtURL  string `json:"cover_art_url,omitempty"`
 }
 
 type Era struct {
@@ -62,33 +101,59 @@ type Era struct {
 	TimeFrame   string `json:"time_frame"`
 }
 
+// UnmarshalJSON accepts an era object, a bare era ID (number or numeric
+// string) or null. A bare ID only sets ID; see Song.EraResolved and
+// Client.HydrateEras.
+func (e *Era) UnmarshalJSON(data []byte) error {
+	type alias Era
+	var obj alias
+	if err := json.Unmarshal(data, &obj); err == nil {
+		*e = Era(obj)
+		return nil
+	}
+	var id json.Number
+	if err := json.Unmarshal(data, &id); err != nil {
+		return fmt.Errorf("era: expected object or ID, got %s", data)
+	}
+	n, err := strconv.Atoi(id.String())
+	if err != nil {
+		return fmt.Errorf("era: invalid ID %s", data)
+	}
+	*e = Era{ID: n}
+	return nil
+}
+
 type Song struct {
-	ID                    int         `json:"id"`
-	Name                  string      `json:"name"`
-	OriginalKey           string      `json:"original_key"`
-	Category              string      `json:"category"`
-	Era                   Era         `json:"era"`
-	TrackTitles           []string    `json:"track_titles"`
-	CreditedArtists       string      `json:"credited_artists"`
-	Producers             string      `json:"producers"`
-	Engineers             string      `json:"engineers"`
-	AdditionalInformation string      `json:"additional_information"`
-	FileNames             string      `json:"file_names"`
-	Instrumentals         string      `json:"instrumentals"`
-	RecordingLocations    string      `json:"recording_locations"`
-	RecordDates           string      `json:"record_dates"`
-	PreviewDate           string      `json:"preview_date"`
-	ReleaseDate           string      `json:"release_date"`
-	Dates                 string      `json:"dates"`
-	Length                string      `json:"length"`
-	LeakType              string      `json:"leak_type"`
-	DateLeaked            string      `json:"date_leaked"`
-	Notes                 string      `json:"notes"`
-	ImageURL              string      `json:"image_url"`
-	SessionTitles         string      `json:"session_titles"`
-	SessionTracking       string      `json:"session_tracking"`
-	InstrumentalNames     string      `json:"instrumental_names"`
-	PublicID              interface{} `json:"public_id"`
+	ID                    int           `json:"id"`
+	Name                  string        `json:"name"`
+	OriginalKey           string        `json:"original_key"`
+	Category              string        `json:"category"`
+	Era                   Era           `json:"era"`
+	TrackTitles           StringOrSlice `json:"track_titles"`
+	CreditedArtists       string        `json:"credited_artists"`
+	Producers             string        `json:"producers"`
+	Engineers             string        `json:"engineers"`
+	AdditionalInformation string        `json:"additional_information"`
+	FileNames             StringOrSlice `json:"file_names"`
+	Instrumentals         string        `json:"instrumentals"`
+	RecordingLocations    string        `json:"recording_locations"`
+	RecordDates           string        `json:"record_dates"`
+	PreviewDate           string        `json:"preview_date"`
+	ReleaseDate           string        `json:"release_date"`
+	Dates                 string        `json:"dates"`
+	Length                string        `json:"length"`
+	LeakType              string        `json:"leak_type"`
+	DateLeaked            string        `json:"date_leaked"`
+	Notes                 string        `json:"notes"`
+	ImageURL              string        `json:"image_url"`
+	SessionTitles         string        `json:"session_titles"`
+	SessionTracking       string        `json:"session_tracking"`
+	InstrumentalNames     StringOrSlice `json:"instrumental_names"`
+	PublicID              interface{}   `json:"public_id"`
+	// Removed is the server's takedown flag, also set by the client for
+	// a Status listed with WithRemovedStatuses; see IsRemoved.
+	Removed bool   `json:"removed,omitempty"`
+	Status  string `json:"status,omitempty"`
 }
 
 type FileInfo struct {
@@ -101,24 +166,235 @@ type FileInfo struct {
 	MimeType  string        `json:"mime_type"`
 	Created   *FlexibleTime `json:"created"`
 	Modified  *FlexibleTime `json:"modified"`
-	Encoding  *string       `json:"encoding"`
+	// Encoding is the character encoding the server detected for the file,
+	// such as "utf-8" for text or "binary" for media. It is nil when the
+	// server did not report one; see EncodingOrDefault.
+	Encoding *FileEncoding `json:"encoding"`
+}
+
+// UnmarshalJSON tolerates a null, missing or quoted size: unknown sizes
+// decode as -1 (see SizeKnown) instead of failing the whole listing.
+func (f *FileInfo) UnmarshalJSON(data []byte) error {
+	type alias FileInfo
+	raw := struct {
+		*alias
+		Size json.RawMessage `json:"size"`
+	}{alias: (*alias)(f)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	f.Size = -1
+	text := strings.Trim(string(raw.Size), `"`)
+	if text == "" || text == "null" {
+		return nil
+	}
+	size, err := strconv.ParseInt(text, 10, 64)
+	if err != nil {
+		return fmt.Errorf("file %q: invalid size %s", f.Name, raw.Size)
+	}
+	f.Size = size
+	return nil
+}
+
+// SizeKnown reports whether the server sent a size for the file.
+func (f FileInfo) SizeKnown() bool {
+	return f.Size >= 0
+}
+
+// decodeFileItem decodes one directory item. A null item is rejected
+// rather than decoded as an empty FileInfo.
+func decodeFileItem(raw json.RawMessage) (FileInfo, error) {
+	var f FileInfo
+	if strings.TrimSpace(string(raw)) == "null" {
+		return f, fmt.Errorf("item is null")
+	}
+	err := json.Unmarshal(raw, &f)
+	return f, err
+}
+
+// DecodeWarning describes a directory item that could not be decoded and
+// was left out of DirectoryInfo.Items.
+type DecodeWarning struct {
+	Index   int    `json:"index"`
+	Message string `json:"message"`
+	Raw     string `json:"raw"`
+}
+
+type PathPart struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
 }
 
 type DirectoryInfo struct {
 	CurrentPath       string              `json:"current_path"`
 	PathParts         []map[string]string `json:"path_parts"`
+	Breadcrumbs       []PathPart          `json:"breadcrumbs"`
 	Items             []FileInfo          `json:"items"`
 	TotalFiles        int                 `json:"total_files"`
 	TotalDirectories  int                 `json:"total_directories"`
 	SearchQuery       *string             `json:"search_query"`
 	IsRecursiveSearch bool                `json:"is_recursive_search"`
+	// DecodeWarnings lists items skipped because they could not be decoded.
+	DecodeWarnings []DecodeWarning `json:"decode_warnings,omitempty"`
+}
+
+func (d *DirectoryInfo) UnmarshalJSON(data []byte) error {
+	type alias DirectoryInfo
+	raw := struct {
+		*alias
+		PathParts   json.RawMessage   `json:"path_parts"`
+		Breadcrumbs json.RawMessage   `json:"breadcrumbs"`
+		Items       []json.RawMessage `json:"items"`
+	}{alias: (*alias)(d)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	d.Items, d.DecodeWarnings = nil, nil
+	for i, item := range raw.Items {
+		f, err := decodeFileItem(item)
+		if err != nil {
+			d.DecodeWarnings = append(d.DecodeWarnings, DecodeWarning{Index: i, Message: err.Error(), Raw: string(item)})
+			continue
+		}
+		d.Items = append(d.Items, f)
+	}
+
+	src := raw.Breadcrumbs
+	if len(src) == 0 || string(src) == "null" {
+		src = raw.PathParts
+	}
+	parts, err := decodePathParts(src)
+	if err != nil {
+		return err
+	}
+	d.Breadcrumbs = parts
+	d.PathParts = nil
+	if len(parts) > 0 {
+		d.PathParts = make([]map[string]string, len(parts))
+		for i, p := range parts {
+			d.PathParts[i] = map[string]string{"name": p.Name, "path": p.Path}
+		}
+	}
+	return nil
+}
+
+func decodePathParts(data []byte) ([]PathPart, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+	var parts []PathPart
+	if err := json.Unmarshal(data, &parts); err == nil {
+		return parts, nil
+	}
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return nil, err
+	}
+	parts = make([]PathPart, len(names))
+	current := ""
+	for i, name := range names {
+		current = path.Join(current, name)
+		parts[i] = PathPart{Name: name, Path: current}
+	}
+	return parts, nil
+}
+
+func (d DirectoryInfo) IsRoot() bool {
+	return strings.Trim(d.CurrentPath, "/") == ""
+}
+
+func (d DirectoryInfo) Parent() string {
+	current := strings.Trim(d.CurrentPath, "/")
+	i := strings.LastIndex(current, "/")
+	if i < 0 {
+		return ""
+	}
+	return current[:i]
+}
+
+// Breadcrumb is one navigation step of a directory listing.
+type Breadcrumb = PathPart
+
+// PathBreadcrumbs returns the path parts of the listing as typed
+// breadcrumbs, from the top-level directory down to the current one.
+func (d DirectoryInfo) PathBreadcrumbs() []Breadcrumb {
+	if d.Breadcrumbs != nil {
+		return d.Breadcrumbs
+	}
+	out := make([]Breadcrumb, 0, len(d.PathParts))
+	for _, p := range d.PathParts {
+		out = append(out, Breadcrumb{Name: p["name"], Path: p["path"]})
+	}
+	return out
+}
+
+// ParentPath returns the path of the last-but-one breadcrumb, or "" at the
+// root. Listings without breadcrumbs fall back to Parent.
+func (d DirectoryInfo) ParentPath() string {
+	crumbs := d.PathBreadcrumbs()
+	if len(crumbs) == 0 {
+		return d.Parent()
+	}
+	if len(crumbs) < 2 {
+		return ""
+	}
+	return crumbs[len(crumbs)-2].Path
+}
+
+func (d DirectoryInfo) Join(child string) string {
+	child = strings.Trim(child, "/")
+	current := strings.Trim(d.CurrentPath, "/")
+	if current == "" {
+		return path.Clean(child)
+	}
+	if child == "" {
+		return current
+	}
+	return path.Join(current, child)
+}
+
+// Size sums the sizes of the files in Items. Recursive search listings
+// already include nested files in Items, so they are counted too. Files
+// whose size is unknown are skipped.
+func (d DirectoryInfo) Size() int64 {
+	var total int64
+	for _, item := range d.Items {
+		if !item.IsDir() && item.SizeKnown() {
+			total += item.Size
+		}
+	}
+	return total
+}
+
+// SizeHuman formats Size with binary units, e.g. "4.2 MB".
+func (d DirectoryInfo) SizeHuman() string {
+	return formatSize(d.Size())
+}
+
+func formatSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
 }
 
 type SearchResult struct {
-	Songs     []Song  `json:"songs"`
-	Total     int     `json:"total"`
-	Category  *string `json:"category"`
-	QueryTime string  `json:"query_time"`
+	// Query is the query as the caller passed it, before normalization.
+	Query    string  `json:"query"`
+	Songs    []Song  `json:"songs"`
+	Total    int     `json:"total"`
+	Category *string `json:"category"`
+	// EffectiveOffset is the offset of the first returned song. Offsets are
+	// rounded down to a page boundary, so it may be less than requested.
+	EffectiveOffset int    `json:"effective_offset"`
+	QueryTime       string `json:"query_time"`
 }
 
 type PaginatedSongsResponse struct {
@@ -126,6 +402,16 @@ type PaginatedSongsResponse struct {
 	Count    int     `json:"count"`
 	Next     *string `json:"next"`
 	Previous *string `json:"previous"`
+
+	// pageSize is the page_size the page was requested with, inherited by
+	// PageFromURL when a link leaves it out.
+	pageSize int
+	// params is the server profile's Params, which PageFromURL reads
+	// links with.
+	params map[string]string
+	// dropped counts the songs removed client-side from Results; see
+	// Dropped.
+	dropped int
 }
 
 type Stats struct {
@@ -133,3 +419,17 @@ type Stats struct {
 	CategoryStats map[string]int `json:"category_stats"`
 	EraStats      map[string]int `json:"era_stats"`
 }
+
+type StreamInfo struct {
+	Status        string `json:"status"`
+	SongID        int    `json:"song_id,omitempty"`
+	StreamURL     string `json:"stream_url"`
+	FilePath      string `json:"file_path"`
+	ContentType   string `json:"content_type"`
+	ContentLength string `json:"content_length"`
+	SupportsRange bool   `json:"supports_range"`
+
+	// size is the file's full size as reported by the probe, -1 if
+	// unknown; resolveAudio ranks candidates by it.
+	size int64
+}
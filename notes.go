@@ -0,0 +1,208 @@
+package juicewrld
+
+import (
+	"errors"
+	"html"
+	"html/template"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	errInvalidUTF8 = errors.New("notes contain invalid UTF-8")
+	paragraphBreak = regexp.MustCompile(`\n[ \t]*\n`)
+)
+
+// NotesHTML renders Notes as HTML. See RenderNotesHTML for the supported
+// markup.
+func (s Song) NotesHTML() (template.HTML, error) {
+	return RenderNotesHTML(s.Notes)
+}
+
+// NotesPlain returns Notes with the markup understood by RenderNotesHTML
+// removed, for terminal display.
+func (s Song) NotesPlain() string {
+	return RenderNotesPlain(s.Notes)
+}
+
+// RenderNotesHTML renders the small markdown subset used in song notes and
+// AdditionalInformation: blank-line separated paragraphs, line breaks,
+// [text](url) links and bare http(s) URLs (rel="nofollow"), **bold** and
+// *italic* or _italic_. Everything else, including raw HTML, is escaped,
+// and links with other schemes are left as text. Invalid UTF-8 is
+// rejected.
+func RenderNotesHTML(text string) (template.HTML, error) {
+	if !utf8.ValidString(text) {
+		return "", errInvalidUTF8
+	}
+	var b strings.Builder
+	for _, para := range notesParagraphs(text) {
+		b.WriteString("<p>")
+		for i, line := range strings.Split(para, "\n") {
+			if i > 0 {
+				b.WriteString("<br>\n")
+			}
+			r := notesRenderer{b: &b, html: true}
+			r.inline(strings.TrimSpace(line), true)
+		}
+		b.WriteString("</p>\n")
+	}
+	return template.HTML(b.String()), nil
+}
+
+// RenderNotesPlain strips the markup RenderNotesHTML understands. Links
+// become "text (url)".
+func RenderNotesPlain(text string) string {
+	text = strings.ToValidUTF8(text, "\uFFFD")
+	var b strings.Builder
+	for i, para := range notesParagraphs(text) {
+		if i > 0 {
+			b.WriteString("\n\n")
+		}
+		for j, line := range strings.Split(para, "\n") {
+			if j > 0 {
+				b.WriteByte('\n')
+			}
+			r := notesRenderer{b: &b}
+			r.inline(strings.TrimSpace(line), true)
+		}
+	}
+	return b.String()
+}
+
+func notesParagraphs(text string) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	var out []string
+	for _, p := range paragraphBreak.Split(text, -1) {
+		if p = strings.Trim(p, "\n"); strings.TrimSpace(p) != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+type notesRenderer struct {
+	b    *strings.Builder
+	html bool
+}
+
+func (r notesRenderer) text(s string) {
+	if r.html {
+		s = html.EscapeString(s)
+	}
+	r.b.WriteString(s)
+}
+
+func (r notesRenderer) tag(t string) {
+	if r.html {
+		r.b.WriteString(t)
+	}
+}
+
+func (r notesRenderer) link(label, href string) {
+	if !r.html {
+		r.b.WriteString(label)
+		if label != href {
+			r.b.WriteString(" (" + href + ")")
+		}
+		return
+	}
+	r.b.WriteString(`<a href="` + html.EscapeString(href) + `" rel="nofollow">`)
+	r.inline(label, false)
+	r.b.WriteString("</a>")
+}
+
+// inline renders one line. links is false inside link labels so links
+// don't nest.
+func (r notesRenderer) inline(s string, links bool) {
+	for i := 0; i < len(s); {
+		rest := s[i:]
+		if links {
+			if label, href, n, ok := parseNotesLink(rest); ok {
+				if r.html {
+					r.link(label, href)
+				} else {
+					r.link(RenderNotesPlain(label), href)
+				}
+				i += n
+				continue
+			}
+			if href, n, ok := parseBareURL(rest); ok && (i == 0 || !isWordByte(s[i-1])) {
+				r.link(href, href)
+				i += n
+				continue
+			}
+		}
+		if strings.HasPrefix(rest, "**") {
+			if j := strings.Index(rest[2:], "**"); j > 0 {
+				r.tag("<strong>")
+				r.inline(rest[2:2+j], links)
+				r.tag("</strong>")
+				i += j + 4
+				continue
+			}
+		}
+		if c := rest[0]; (c == '*' || (c == '_' && (i == 0 || !isWordByte(s[i-1])))) && len(rest) > 1 && rest[1] != ' ' {
+			if j := strings.IndexByte(rest[1:], c); j > 0 && (c == '*' || 1+j+1 >= len(rest) || !isWordByte(rest[1+j+1])) {
+				r.tag("<em>")
+				r.inline(rest[1:1+j], links)
+				r.tag("</em>")
+				i += j + 2
+				continue
+			}
+		}
+		_, size := utf8.DecodeRuneInString(rest)
+		r.text(rest[:size])
+		i += size
+	}
+}
+
+func parseNotesLink(s string) (label, href string, n int, ok bool) {
+	if !strings.HasPrefix(s, "[") {
+		return "", "", 0, false
+	}
+	end := strings.Index(s, "](")
+	if end < 2 {
+		return "", "", 0, false
+	}
+	close := strings.IndexByte(s[end+2:], ')')
+	if close < 1 {
+		return "", "", 0, false
+	}
+	href = s[end+2 : end+2+close]
+	if strings.ContainsAny(href, " \t") || !safeLinkURL(href) {
+		return "", "", 0, false
+	}
+	return s[1:end], href, end + 3 + close, true
+}
+
+func parseBareURL(s string) (href string, n int, ok bool) {
+	if !strings.HasPrefix(s, "http://") && !strings.HasPrefix(s, "https://") {
+		return "", 0, false
+	}
+	n = strings.IndexFunc(s, unicode.IsSpace)
+	if n < 0 {
+		n = len(s)
+	}
+	n = len(strings.TrimRight(s[:n], ".,;:!?)]\"'"))
+	href = s[:n]
+	if !safeLinkURL(href) {
+		return "", 0, false
+	}
+	return href, n, true
+}
+
+func safeLinkURL(href string) bool {
+	u, err := url.Parse(href)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	return u.Scheme == "http" || u.Scheme == "https"
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
@@ -0,0 +1,141 @@
+package juicewrld
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestRenderNotesHTML(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"", ""},
+		{"Leaked in 2019.", "<p>Leaked in 2019.</p>\n"},
+		{"line one\nline two\n\nsecond para", "<p>line one<br>\nline two</p>\n<p>second para</p>\n"},
+		{"**OG** file, *snippet* and _tagged_", "<p><strong>OG</strong> file, <em>snippet</em> and <em>tagged</em></p>\n"},
+		{"snake_case_name stays", "<p>snake_case_name stays</p>\n"},
+		{"[thread](https://example.com/t?a=1&b=2)", `<p><a href="https://example.com/t?a=1&amp;b=2" rel="nofollow">thread</a></p>` + "\n"},
+		{"see https://example.com/x.", `<p>see <a href="https://example.com/x" rel="nofollow">https://example.com/x</a>.</p>` + "\n"},
+		{"[**bold** link](http://example.com)", `<p><a href="http://example.com" rel="nofollow"><strong>bold</strong> link</a></p>` + "\n"},
+		{"<script>alert(1)</script>", "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>\n"},
+		{"[x](javascript:alert(1))", "<p>[x](javascript:alert(1))</p>\n"},
+		{`[x](https://e.com/"onmouseover="a)`, `<p><a href="https://e.com/&#34;onmouseover=&#34;a" rel="nofollow">x</a></p>` + "\n"},
+		{"[outer [inner](https://a.com)](https://b.com)", `<p><a href="https://a.com" rel="nofollow">outer [inner</a>](<a href="https://b.com" rel="nofollow">https://b.com</a>)</p>` + "\n"},
+	}
+	for _, tt := range tests {
+		got, err := RenderNotesHTML(tt.in)
+		if err != nil || string(got) != tt.want {
+			t.Errorf("RenderNotesHTML(%q) = %q, %v\nwant %q", tt.in, got, err, tt.want)
+		}
+	}
+	if _, err := RenderNotesHTML("bad \xff byte"); err == nil {
+		t.Error("invalid UTF-8 accepted")
+	}
+}
+
+func TestRenderNotesPlain(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"**OG** file\r\n\r\n*snippet*", "OG file\n\nsnippet"},
+		{"[thread](https://example.com/t)", "thread (https://example.com/t)"},
+		{"https://example.com/x", "https://example.com/x"},
+		{"<b>kept</b>", "<b>kept</b>"},
+		{"bad \xff byte", "bad � byte"},
+	}
+	for _, tt := range tests {
+		if got := RenderNotesPlain(tt.in); got != tt.want {
+			t.Errorf("RenderNotesPlain(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+// notesTags are the only markup RenderNotesHTML may emit.
+var notesTags = []string{"<p>", "</p>", "<br>", "<strong>", "</strong>", "<em>", "</em>", "</a>"}
+
+// checkNotesHTML reports the first '<' in out that does not open one of
+// notesTags or a nofollow link whose href cannot break out of its quotes.
+func checkNotesHTML(out string) (string, bool) {
+	depth := map[string]int{}
+	for i := 0; i < len(out); {
+		j := strings.IndexByte(out[i:], '<')
+		if j < 0 {
+			break
+		}
+		rest := out[i+j:]
+		if strings.HasPrefix(rest, `<a href="`) {
+			end := strings.Index(rest, `" rel="nofollow">`)
+			if end < 0 || strings.ContainsAny(rest[len(`<a href="`):end], `<>"`) {
+				return rest, false
+			}
+			depth["a"]++
+			i += j + end + len(`" rel="nofollow">`)
+			continue
+		}
+		n := 0
+		for _, tag := range notesTags {
+			if strings.HasPrefix(rest, tag) {
+				n = len(tag)
+				name := strings.Trim(tag, "</>")
+				if tag[1] == '/' {
+					depth[name]--
+				} else if name != "br" {
+					depth[name]++
+				}
+				break
+			}
+		}
+		if n == 0 {
+			return rest, false
+		}
+		i += j + n
+	}
+	for name, d := range depth {
+		if d != 0 {
+			return "unbalanced <" + name + ">", false
+		}
+	}
+	return "", true
+}
+
+func FuzzRenderNotesHTML(f *testing.F) {
+	for _, seed := range []string{
+		"**OG** file\n\n[thread](https://example.com/t?a=1&b=2)",
+		"<script>alert(1)</script>",
+		"<img src=x onerror=alert(1)>",
+		"[x](javascript:alert(1))",
+		`[<b>](https://e.com/"><script>)`,
+		"https://e.com/<script>",
+		"**[*_x_*](http://a.b)**",
+		"<<**>>*<*>_<_>",
+		"[a](http://b.c/\x00)",
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, in string) {
+		out, err := RenderNotesHTML(in)
+		if !utf8.ValidString(in) {
+			if err == nil {
+				t.Error("invalid UTF-8 accepted")
+			}
+			return
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if at, ok := checkNotesHTML(string(out)); !ok {
+			t.Errorf("RenderNotesHTML(%q) = %q: unexpected markup at %q", in, out, at)
+		}
+	})
+}
+
+func FuzzRenderNotesPlain(f *testing.F) {
+	f.Add("**OG** [thread](https://example.com/t)\r\n\r\n*x*")
+	f.Add("bad \xff byte")
+	f.Fuzz(func(t *testing.T, in string) {
+		if out := RenderNotesPlain(in); !utf8.ValidString(out) {
+			t.Errorf("RenderNotesPlain(%q) = %q, not valid UTF-8", in, out)
+		}
+	})
+}
@@ -0,0 +1,236 @@
+package juicewrld
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ErrOffline matches every OfflineError.
+var ErrOffline = errors.New("client is offline")
+
+// OfflineError reports a write attempted while the client serves requests
+// from WithOfflineSource.
+type OfflineError struct {
+	Method string
+	Path   string
+}
+
+func (e *OfflineError) Error() string {
+	return fmt.Sprintf("%s %s: client is offline", e.Method, e.Path)
+}
+
+func (e *OfflineError) Is(target error) bool { return target == ErrOffline }
+
+// WithOfflineSource serves every request from a local directory instead of
+// the network. The directory mirrors the API's URL paths:
+//
+//	juicewrld/songs/index.json             GET /juicewrld/songs/
+//	juicewrld/songs/page-2.json            GET /juicewrld/songs/?page=2
+//	juicewrld/songs/42/index.json          GET /juicewrld/songs/42/
+//	juicewrld/files/browse/Dir/index.json  GET /juicewrld/files/browse/?path=Dir
+//	juicewrld/files/info/Dir/a.mp3.json    GET /juicewrld/files/info/?path=Dir/a.mp3
+//	juicewrld/files/download/Dir/a.mp3     GET /juicewrld/files/download/?path=Dir/a.mp3
+//
+// Pagination links inside stored pages resolve to the page files, so
+// iterators work unchanged. Anything absent, including filtered or search
+// queries, is a NotFoundError; requests other than GET and HEAD fail with
+// an OfflineError. Downloads honour single byte ranges. ExportStaticMirror
+// writes this layout from a live server.
+func WithOfflineSource(dir string) Option {
+	return func(c *Client) {
+		c.offlineDir = dir
+	}
+}
+
+func (c *Client) installOffline() {
+	if c.offlineDir == "" {
+		return
+	}
+	hc := *c.HTTPClient
+	hc.Transport = &offlineTransport{client: c, dir: c.offlineDir}
+	c.HTTPClient = &hc
+}
+
+type offlineTransport struct {
+	client *Client
+	dir    string
+}
+
+// offlinePathParamEndpoints take the file path from the "path" parameter;
+// the bool says whether the stored file is JSON.
+var offlinePathParamEndpoints = map[string]bool{
+	"juicewrld/files/browse":      true,
+	"juicewrld/files/info":        true,
+	"juicewrld/files/fingerprint": true,
+	"juicewrld/files/download":    false,
+	"juicewrld/files/cover-art":   false,
+}
+
+func (t *offlineTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return nil, &OfflineError{Method: req.Method, Path: req.URL.Path}
+	}
+	endpoint := strings.Trim(req.URL.Path, "/")
+	if base, err := t.client.baseURL(); err == nil {
+		endpoint = strings.Trim(strings.TrimPrefix(endpoint, strings.Trim(base.Path, "/")), "/")
+	}
+	name, isJSON, ok := offlineFile(endpoint, req.URL.Query())
+	if !ok {
+		return offlineResponse(req, http.StatusNotFound, "offline source has no data for "+req.URL.RequestURI(), nil), nil
+	}
+	f, err := os.Open(filepath.Join(t.dir, filepath.FromSlash(name)))
+	if errors.Is(err, os.ErrNotExist) {
+		return offlineResponse(req, http.StatusNotFound, "offline source has no "+name, nil), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	st, err := f.Stat()
+	if err != nil || st.IsDir() {
+		f.Close()
+		return offlineResponse(req, http.StatusNotFound, "offline source has no "+name, nil), nil
+	}
+	contentType := "application/json"
+	if !isJSON {
+		contentType = mime.TypeByExtension(path.Ext(name))
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+	}
+	return serveOfflineFile(req, f, st.Size(), contentType), nil
+}
+
+// offlineFile maps an endpoint and query to its file in the offline
+// directory.
+func offlineFile(endpoint string, q map[string][]string) (name string, isJSON, ok bool) {
+	get := func(k string) string {
+		if v := q[k]; len(v) > 0 {
+			return v[0]
+		}
+		return ""
+	}
+	if isJSON, known := offlinePathParamEndpoints[endpoint]; known {
+		p := strings.Trim(path.Clean("/"+get("path")), "/")
+		switch {
+		case !isJSON && p == "":
+			return "", false, false
+		case !isJSON:
+			return endpoint + "/" + p, false, true
+		case endpoint == "juicewrld/files/browse":
+			if get("search") != "" {
+				return "", false, false
+			}
+			return path.Join(endpoint, p, "index.json"), true, true
+		case p == "":
+			return "", false, false
+		default:
+			return endpoint + "/" + p + ".json", true, true
+		}
+	}
+	for k := range q {
+		if k != "page" && k != "page_size" {
+			return "", false, false
+		}
+	}
+	if page, err := strconv.Atoi(get("page")); err == nil && page > 1 {
+		return endpoint + "/page-" + strconv.Itoa(page) + ".json", true, true
+	}
+	return endpoint + "/index.json", true, true
+}
+
+func serveOfflineFile(req *http.Request, f *os.File, size int64, contentType string) *http.Response {
+	header := http.Header{"Content-Type": {contentType}, "Accept-Ranges": {"bytes"}}
+	status, start, length := http.StatusOK, int64(0), size
+	if r := req.Header.Get("Range"); r != "" {
+		s, e, ok := parseByteRange(r, size)
+		if !ok {
+			f.Close()
+			header.Set("Content-Range", fmt.Sprintf("bytes */%d", size))
+			return offlineResponse(req, http.StatusRequestedRangeNotSatisfiable, "", header)
+		}
+		status, start, length = http.StatusPartialContent, s, e-s+1
+		header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", s, e, size))
+	}
+	header.Set("Content-Length", strconv.FormatInt(length, 10))
+	var body io.ReadCloser = struct {
+		io.Reader
+		io.Closer
+	}{io.NewSectionReader(f, start, length), f}
+	if req.Method == http.MethodHead {
+		f.Close()
+		body = http.NoBody
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          body,
+		ContentLength: length,
+		Request:       req,
+	}
+}
+
+// parseByteRange parses a single "bytes=start-end", "bytes=start-" or
+// "bytes=-suffix" range against size.
+func parseByteRange(v string, size int64) (start, end int64, ok bool) {
+	spec, found := strings.CutPrefix(strings.TrimSpace(v), "bytes=")
+	if !found || strings.Contains(spec, ",") {
+		return 0, 0, false
+	}
+	first, last, found := strings.Cut(spec, "-")
+	if !found {
+		return 0, 0, false
+	}
+	if first == "" {
+		n, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || n <= 0 {
+			return 0, 0, false
+		}
+		return max(size-n, 0), size - 1, size > 0
+	}
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || start < 0 || start >= size {
+		return 0, 0, false
+	}
+	end = size - 1
+	if last != "" {
+		if end, err = strconv.ParseInt(last, 10, 64); err != nil || end < start {
+			return 0, 0, false
+		}
+		end = min(end, size-1)
+	}
+	return start, end, true
+}
+
+func offlineResponse(req *http.Request, status int, msg string, header http.Header) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set("Content-Type", "text/plain; charset=utf-8")
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader([]byte(msg))),
+		ContentLength: int64(len(msg)),
+		Request:       req,
+	}
+}
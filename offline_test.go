@@ -0,0 +1,205 @@
+package juicewrld
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newCatalogAPI serves a two-page song listing, one album, era and artist,
+// categories, stats and a small file tree holding a.mp3 and Sub/b.mp3.
+func newCatalogAPI(t *testing.T) *fakeAPI {
+	t.Helper()
+	var api *fakeAPI
+	songsPage := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"count": 3, "next": nil, "previous": api.URL + "/juicewrld/songs/",
+				"results": []map[string]interface{}{{"id": 3, "name": "Wishing Well"}},
+			})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"count": 3, "next": api.URL + "/juicewrld/songs/?page=2", "previous": nil,
+			"results": []map[string]interface{}{
+				{"id": 1, "name": "Lucid Dreams", "unknown_field": "kept"},
+				{"id": 2, "name": "Robbery"},
+			},
+		})
+	}
+	files := map[string][]map[string]interface{}{
+		"":    {{"name": "Sub", "type": "directory", "path": "Sub"}, {"name": "a.mp3", "type": "file", "path": "a.mp3", "size": 5}},
+		"Sub": {{"name": "b.mp3", "type": "file", "path": "Sub/b.mp3", "size": 3}},
+	}
+	content := map[string]string{"a.mp3": "hello", "Sub/b.mp3": "bye"}
+	routes := map[string]http.HandlerFunc{
+		"/juicewrld/songs/": songsPage,
+		"/juicewrld/files/browse/": func(w http.ResponseWriter, r *http.Request) {
+			p := r.URL.Query().Get("path")
+			writeJSON(w, http.StatusOK, map[string]interface{}{"current_path": p, "items": files[p]})
+		},
+		"/juicewrld/files/info/": func(w http.ResponseWriter, r *http.Request) {
+			p := r.URL.Query().Get("path")
+			writeJSON(w, http.StatusOK, map[string]interface{}{"name": filepath.Base(p), "path": p, "size": len(content[p])})
+		},
+		"/juicewrld/files/download/": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "audio/mpeg")
+			io.WriteString(w, content[r.URL.Query().Get("path")])
+		},
+		"/juicewrld/categories/": jsonHandler(map[string]interface{}{"categories": []map[string]string{{"value": "released"}}}),
+		"/juicewrld/stats/":      jsonHandler(map[string]interface{}{"total_songs": 3}),
+	}
+	for i, name := range []string{"albums", "eras", "artists"} {
+		item := map[string]interface{}{"id": i + 1, "name": name + " one", "title": name + " one"}
+		routes["/juicewrld/"+name+"/"] = jsonHandler(map[string]interface{}{"count": 1, "results": []interface{}{item}})
+		routes[fmt.Sprintf("/juicewrld/%s/%d/", name, i+1)] = jsonHandler(item)
+	}
+	for _, id := range []int{1, 2, 3} {
+		routes[fmt.Sprintf("/juicewrld/songs/%d/", id)] = jsonHandler(map[string]interface{}{"id": id, "name": fmt.Sprintf("song %d", id)})
+	}
+	api = newFakeAPI(t, routes)
+	return api
+}
+
+func TestExportStaticMirrorServesOffline(t *testing.T) {
+	api := newCatalogAPI(t)
+	dir := t.TempDir()
+	ctx := context.Background()
+	err := api.client().ExportStaticMirror(ctx, dir, &MirrorOptions{Files: true, FileInfo: true, Downloads: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(dir, "juicewrld", "songs", "index.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(raw), `"unknown_field":"kept"`) {
+		t.Errorf("stored page lost fields the client does not decode: %s", raw)
+	}
+
+	c := New("http://offline.invalid", WithOfflineSource(dir))
+	songs, err := c.GetAllSongs(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(songs) != 3 {
+		t.Errorf("offline GetAllSongs returned %d songs, want 3", len(songs))
+	}
+	song, err := c.GetSong(ctx, 2)
+	if err != nil || song.Name != "song 2" {
+		t.Errorf("offline GetSong(2) = %+v, %v", song, err)
+	}
+	if album, err := c.GetAlbum(ctx, 1); err != nil || album.Title != "albums one" {
+		t.Errorf("offline GetAlbum(1) = %+v, %v", album, err)
+	}
+	if st, err := c.GetStats(ctx); err != nil || st.TotalSongs != 3 {
+		t.Errorf("offline GetStats = %+v, %v", st, err)
+	}
+	listing, err := c.BrowseFiles(ctx, "Sub", nil)
+	if err != nil || len(listing.Items) != 1 {
+		t.Fatalf("offline BrowseFiles(Sub) = %+v, %v", listing, err)
+	}
+	if info, err := c.GetFileInfo(ctx, "Sub/b.mp3"); err != nil || info.Size != 3 {
+		t.Errorf("offline GetFileInfo = %+v, %v", info, err)
+	}
+	data, err := c.DownloadFile(ctx, "a.mp3")
+	if err != nil || string(data) != "hello" {
+		t.Errorf("offline DownloadFile = %q, %v", data, err)
+	}
+
+	var nf *NotFoundError
+	if _, err := c.GetSong(ctx, 99); !errors.As(err, &nf) {
+		t.Errorf("missing song: err = %v, want NotFoundError", err)
+	}
+	if _, err := c.StartZipJob(ctx, []string{"a.mp3"}); !errors.Is(err, ErrOffline) {
+		t.Errorf("write while offline: err = %v, want ErrOffline", err)
+	}
+}
+
+func TestExportStaticMirrorLimits(t *testing.T) {
+	api := newCatalogAPI(t)
+	dir := t.TempDir()
+	ctx := context.Background()
+	err := api.client().ExportStaticMirror(ctx, dir, &MirrorOptions{MaxSongPages: 1, DetailLimit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"songs/page-2.json", "songs/2/index.json", "files/browse/index.json"} {
+		if _, err := os.Stat(filepath.Join(dir, "juicewrld", filepath.FromSlash(name))); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("%s exported despite the limits (err = %v)", name, err)
+		}
+	}
+
+	c := New("http://offline.invalid", WithOfflineSource(dir))
+	songs, err := c.GetAllSongs(ctx, nil)
+	if err != nil {
+		t.Fatalf("iterating a truncated mirror: %v", err)
+	}
+	if len(songs) != 2 {
+		t.Errorf("got %d songs from one exported page, want 2", len(songs))
+	}
+	if _, err := c.GetSong(ctx, 1); err != nil {
+		t.Errorf("first song's detail record missing: %v", err)
+	}
+
+	// The detail limit spans all song pages, not each one.
+	all := t.TempDir()
+	if err := api.client().ExportStaticMirror(ctx, all, &MirrorOptions{DetailLimit: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(all, "juicewrld", "songs", "3", "index.json")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("second page's song detail exported despite DetailLimit 1 (err = %v)", err)
+	}
+}
+
+func TestOfflineDownloadRanges(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "juicewrld", "files", "download", "a.mp3")
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(name, []byte("0123456789"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c := New("http://offline.invalid", WithOfflineSource(dir))
+
+	tests := []struct {
+		header string
+		status int
+		body   string
+	}{
+		{"bytes=2-4", http.StatusPartialContent, "234"},
+		{"bytes=7-", http.StatusPartialContent, "789"},
+		{"bytes=-2", http.StatusPartialContent, "89"},
+		{"bytes=20-", http.StatusRequestedRangeNotSatisfiable, ""},
+	}
+	for _, tt := range tests {
+		resp, err := c.OpenStream(context.Background(), "a.mp3", tt.header)
+		if tt.status >= 400 {
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.status {
+				t.Errorf("%s: err = %v, want status %d", tt.header, err, tt.status)
+			}
+			if err == nil {
+				resp.Body.Close()
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.header, err)
+			continue
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != tt.status || string(body) != tt.body {
+			t.Errorf("%s: got %d %q, want %d %q", tt.header, resp.StatusCode, body, tt.status, tt.body)
+		}
+	}
+}
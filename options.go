@@ -0,0 +1,66 @@
+package juicewrld
+
+import (
+	"net/http"
+	"time"
+)
+
+type Option func(*Client)
+
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		if hc != nil {
+			c.HTTPClient = hc
+		}
+	}
+}
+
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = d
+		if c.HTTPClient != nil {
+			c.HTTPClient.Timeout = d
+		}
+	}
+}
+
+func WithUserAgent(ua string) Option {
+	return func(c *Client) {
+		if ua != "" {
+			c.userAgent = ua
+		}
+	}
+}
+
+func WithRetry(maxRetries int, baseDelay time.Duration) Option {
+	return func(c *Client) {
+		if maxRetries < 0 {
+			maxRetries = 0
+		}
+		c.maxRetries = maxRetries
+		if baseDelay > 0 {
+			c.retryBaseDelay = baseDelay
+		}
+	}
+}
+
+func WithConcurrency(n int) Option {
+	return func(c *Client) {
+		if n > 0 {
+			c.concurrency = n
+		}
+	}
+}
+
+// WithCache makes GetArtists, GetAlbums, GetEras, GetCategories and GetStats
+// read through the in-memory cache, as well as unfiltered GetSongs pages
+// cached by WarmUpSongs. Entries expire after ttl; a ttl of 0 keeps the
+// default of 10 minutes.
+func WithCache(ttl time.Duration) Option {
+	return func(c *Client) {
+		c.cacheReads = true
+		if ttl > 0 {
+			c.lookupTTL = ttl
+		}
+	}
+}
@@ -0,0 +1,275 @@
+package juicewrld
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// PaginationKeys names the top-level fields of a paginated response. The
+// defaults follow Django REST Framework: "results", "count", "next" and
+// "previous".
+type PaginationKeys struct {
+	Results  string
+	Count    string
+	Next     string
+	Previous string
+}
+
+var DefaultPaginationKeys = PaginationKeys{
+	Results:  "results",
+	Count:    "count",
+	Next:     "next",
+	Previous: "previous",
+}
+
+// WithPaginationKeys sets the envelope field names. It takes precedence
+// over the keys of a server profile, whichever option comes first.
+func WithPaginationKeys(keys PaginationKeys) Option {
+	return func(c *Client) {
+		c.pageKeys = keys.withDefaults()
+	}
+}
+
+func (k PaginationKeys) withDefaults() PaginationKeys {
+	if k.Results == "" {
+		k.Results = DefaultPaginationKeys.Results
+	}
+	if k.Count == "" {
+		k.Count = DefaultPaginationKeys.Count
+	}
+	if k.Next == "" {
+		k.Next = DefaultPaginationKeys.Next
+	}
+	if k.Previous == "" {
+		k.Previous = DefaultPaginationKeys.Previous
+	}
+	return k
+}
+
+// paginationKeys returns the keys set with WithPaginationKeys, else those
+// of the server profile, else the defaults.
+func (c *Client) paginationKeys() PaginationKeys {
+	if c.pageKeys != (PaginationKeys{}) {
+		return c.pageKeys
+	}
+	return c.profile.PaginationKeys.withDefaults()
+}
+
+func (c *Client) getPage(ctx context.Context, path string, q url.Values, out interface{}) error {
+	var raw json.RawMessage
+	if err := c.get(ctx, path, q, &raw); err != nil {
+		return err
+	}
+	return c.decodeList(raw, out)
+}
+
+func (c *Client) getPageURL(ctx context.Context, rawURL string, out interface{}) error {
+	var raw json.RawMessage
+	if err := c.doURL(ctx, http.MethodGet, rawURL, nil, false, &raw); err != nil {
+		return err
+	}
+	return c.decodeList(raw, out)
+}
+
+func (c *Client) decodePage(raw map[string]json.RawMessage, out interface{}) error {
+	keys := c.paginationKeys()
+	results, ok := raw[keys.Results]
+	if !ok {
+		b, _ := json.Marshal(raw)
+		return fmt.Errorf("paginated response has no %q field: %s", keys.Results, b)
+	}
+	canonical := map[string]json.RawMessage{"results": results}
+	for canon, key := range map[string]string{"count": keys.Count, "next": keys.Next, "previous": keys.Previous} {
+		if v, ok := raw[key]; ok {
+			canonical[canon] = v
+		}
+	}
+	if _, ok := canonical["count"]; !ok && c.profile.MissingCount {
+		var items []json.RawMessage
+		if json.Unmarshal(results, &items) == nil {
+			canonical["count"] = json.RawMessage(strconv.Itoa(len(items)))
+		}
+	}
+	buf, err := json.Marshal(canonical)
+	if err != nil {
+		return err
+	}
+	return c.unmarshal(buf, out)
+}
+
+// Page is one page of a paginated endpoint, with the envelope fields
+// renamed according to the client's PaginationKeys.
+type Page[T any] struct {
+	Results  []T     `json:"results"`
+	Count    int     `json:"count"`
+	Next     *string `json:"next"`
+	Previous *string `json:"previous"`
+}
+
+// Paginator walks a paginated endpoint page by page by following next
+// links. Supporting a new paginated endpoint only needs its path:
+//
+//	p := juicewrld.NewPaginator[juicewrld.Album](c, "/juicewrld/albums/", nil)
+//	for {
+//		albums, ok, err := p.Next(ctx)
+//		if err != nil || !ok {
+//			break
+//		}
+//		...
+//	}
+type Paginator[T any] struct {
+	client *Client
+	path   string
+	query  url.Values
+
+	next    string
+	started bool
+	done    bool
+	count   int
+	seen    map[string]bool
+}
+
+func NewPaginator[T any](c *Client, path string, query url.Values) *Paginator[T] {
+	return &Paginator[T]{client: c, path: path, query: query, seen: map[string]bool{}}
+}
+
+// Next fetches the next page. It returns false once the last page has been
+// returned; a next link seen before also ends the walk, so a server that
+// loops its links cannot trap the caller.
+func (p *Paginator[T]) Next(ctx context.Context) ([]T, bool, error) {
+	if p.done {
+		return nil, false, nil
+	}
+	var page Page[T]
+	var err error
+	if !p.started {
+		err = p.client.getPage(ctx, p.path, p.query, &page)
+	} else {
+		err = p.client.getPageURL(ctx, p.next, &page)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	p.started = true
+	p.count = page.Count
+	p.next = derefString(page.Next)
+	if p.next == "" || p.seen[p.next] || len(page.Results) == 0 {
+		p.done = true
+	}
+	p.seen[p.next] = true
+	return page.Results, true, nil
+}
+
+// Count returns the total reported by the most recent page.
+func (p *Paginator[T]) Count() int {
+	return p.count
+}
+
+// All fetches the remaining pages and returns their items. On error it
+// returns the items gathered so far together with the error.
+func (p *Paginator[T]) All(ctx context.Context) ([]T, error) {
+	var out []T
+	for {
+		items, ok, err := p.Next(ctx)
+		if err != nil {
+			return out, err
+		}
+		if !ok {
+			return out, nil
+		}
+		out = append(out, items...)
+	}
+}
+
+// ErrCursorPagination is returned by PageFromURL for cursor-style links,
+// which carry no page number.
+var ErrCursorPagination = errors.New("pagination link is cursor-based")
+
+// PageFromURL extracts the page number and page size from a pagination
+// link, under the parameter names of the server profile the page was
+// fetched with, or the canonical page and page_size (or per_page). A link
+// without a page parameter points at page 1, as the server omits it
+// there. When the link has no page size, the one the page was requested
+// with is used (0 if unknown).
+func (r PaginatedSongsResponse) PageFromURL(u string) (page, pageSize int, err error) {
+	parsed, err := url.Parse(strings.TrimSpace(u))
+	if err != nil {
+		return 0, 0, err
+	}
+	q := parsed.Query()
+	if q.Has("cursor") {
+		return 0, 0, ErrCursorPagination
+	}
+	page = 1
+	for _, key := range []string{r.param("page"), "page"} {
+		if v := q.Get(key); v != "" {
+			if page, err = strconv.Atoi(v); err != nil || page < 1 {
+				return 0, 0, fmt.Errorf("invalid %s %q in pagination link", key, v)
+			}
+			break
+		}
+	}
+	pageSize = r.pageSize
+	for _, key := range []string{r.param("page_size"), "page_size", "per_page"} {
+		if v := q.Get(key); v != "" {
+			if pageSize, err = strconv.Atoi(v); err != nil || pageSize < 1 {
+				return 0, 0, fmt.Errorf("invalid %s %q in pagination link", key, v)
+			}
+			break
+		}
+	}
+	return page, pageSize, nil
+}
+
+// param is the wire name of a canonical query parameter.
+func (r PaginatedSongsResponse) param(name string) string {
+	if wire := r.params[name]; wire != "" {
+		return wire
+	}
+	return name
+}
+
+// NextPage returns the page number of the next link; false when there is
+// no next page or the link can't be read as a page number.
+func (r PaginatedSongsResponse) NextPage() (int, bool) {
+	return r.linkPage(r.Next)
+}
+
+// PreviousPage is NextPage for the previous link.
+func (r PaginatedSongsResponse) PreviousPage() (int, bool) {
+	return r.linkPage(r.Previous)
+}
+
+func (r PaginatedSongsResponse) linkPage(link *string) (int, bool) {
+	if link == nil || *link == "" {
+		return 0, false
+	}
+	page, _, err := r.PageFromURL(*link)
+	return page, err == nil
+}
+
+// TotalPages returns how many server pages of pageSize cover Count. A
+// pageSize of 0 uses the size the page was requested with or, failing
+// that, the server's length of this page, dropped songs included, when it
+// isn't the last one. It returns 0 when the size can't be determined.
+func (r PaginatedSongsResponse) TotalPages(pageSize int) int {
+	if pageSize <= 0 {
+		pageSize = r.pageSize
+	}
+	if pageSize <= 0 && r.Next != nil {
+		pageSize = len(r.Results) + r.dropped
+	}
+	if pageSize <= 0 {
+		if r.Next == nil && r.Previous == nil && r.Count > 0 {
+			return 1
+		}
+		return 0
+	}
+	return (r.Count + pageSize - 1) / pageSize
+}
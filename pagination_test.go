@@ -0,0 +1,67 @@
+package juicewrld
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestPageFromURL(t *testing.T) {
+	r := PaginatedSongsResponse{pageSize: 20}
+	tests := []struct {
+		link           string
+		page, pageSize int
+		wantErr        bool
+	}{
+		{"https://juicewrldapi.com/juicewrld/songs/?page=3&page_size=50", 3, 50, false},
+		{"https://juicewrldapi.com/juicewrld/songs/?page=2", 2, 20, false},
+		{"https://juicewrldapi.com/juicewrld/songs/", 1, 20, false},
+		{"https://mirror.example/songs?page=4&per_page=10", 4, 10, false},
+		{"https://juicewrldapi.com/juicewrld/songs/?page=0", 0, 0, true},
+		{"https://juicewrldapi.com/juicewrld/songs/?page=2&page_size=x", 0, 0, true},
+	}
+	for _, tt := range tests {
+		page, pageSize, err := r.PageFromURL(tt.link)
+		if (err != nil) != tt.wantErr || page != tt.page || pageSize != tt.pageSize {
+			t.Errorf("PageFromURL(%q) = %d, %d, %v; want %d, %d, error %v", tt.link, page, pageSize, err, tt.page, tt.pageSize, tt.wantErr)
+		}
+	}
+	if _, _, err := r.PageFromURL("https://juicewrldapi.com/juicewrld/songs/?cursor=abc"); !errors.Is(err, ErrCursorPagination) {
+		t.Errorf("cursor link: err = %v, want ErrCursorPagination", err)
+	}
+}
+
+func TestPageFromURLUsesProfileParams(t *testing.T) {
+	profile := Profile{
+		Name:   "custom",
+		Params: map[string]string{"page": "p", "page_size": "limit"},
+	}
+	var api *fakeAPI
+	api = newFakeAPI(t, map[string]http.HandlerFunc{
+		"/juicewrld/songs/": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("p") != "2" || r.URL.Query().Get("limit") != "5" {
+				t.Errorf("query %q does not use the profile's names", r.URL.RawQuery)
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"count":    20,
+				"results":  []map[string]interface{}{{"id": 1, "name": "Song"}},
+				"next":     api.URL + "/juicewrld/songs/?p=3&limit=5",
+				"previous": api.URL + "/juicewrld/songs/?limit=5",
+			})
+		},
+	})
+	page, err := api.client(WithServerProfile(profile)).GetSongs(context.Background(), 2, nil, nil, nil, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, size, err := page.PageFromURL(*page.Next); err != nil || n != 3 || size != 5 {
+		t.Errorf("PageFromURL(next) = %d, %d, %v; want 3, 5", n, size, err)
+	}
+	if n, ok := page.NextPage(); !ok || n != 3 {
+		t.Errorf("NextPage = %d, %v; want 3", n, ok)
+	}
+	if n, ok := page.PreviousPage(); !ok || n != 1 {
+		t.Errorf("PreviousPage = %d, %v; want 1", n, ok)
+	}
+}
@@ -0,0 +1,98 @@
+package juicewrld
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+	"net/http"
+	"strings"
+)
+
+// WithCertificatePinning pins TLS connections to the BaseURL host to the
+// given base64-encoded SHA-256 hashes of SubjectPublicKeyInfo. A connection
+// is accepted when any presented certificate matches any pin, so backup pins
+// for key rotation are simply listed alongside the current one. Other hosts
+// are not pinned. Pinning applies to the client's *http.Transport; a custom
+// RoundTripper supplied through WithHTTPClient is left untouched.
+func WithCertificatePinning(pins []string) Option {
+	return func(c *Client) {
+		for _, p := range pins {
+			if p = strings.TrimSpace(strings.TrimPrefix(p, "sha256/")); p != "" {
+				c.pins = append(c.pins, p)
+			}
+		}
+	}
+}
+
+func spkiHash(der []byte) string {
+	sum := sha256.Sum256(der)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func (c *Client) installPinning() {
+	if len(c.pins) == 0 {
+		return
+	}
+	var base *http.Transport
+	switch t := c.HTTPClient.Transport.(type) {
+	case nil:
+		base = http.DefaultTransport.(*http.Transport)
+	case *http.Transport:
+		base = t
+	default:
+		return
+	}
+	pinned := base.Clone()
+	if pinned.TLSClientConfig == nil {
+		pinned.TLSClientConfig = &tls.Config{}
+	}
+	next := pinned.TLSClientConfig.VerifyConnection
+	pinned.TLSClientConfig.VerifyConnection = func(cs tls.ConnectionState) error {
+		if next != nil {
+			if err := next(cs); err != nil {
+				return err
+			}
+		}
+		return c.verifyPins(cs)
+	}
+	hc := *c.HTTPClient
+	hc.Transport = &pinningTransport{client: c, pinned: pinned, other: base}
+	c.HTTPClient = &hc
+}
+
+type pinningTransport struct {
+	client *Client
+	pinned *http.Transport
+	other  *http.Transport
+}
+
+func (t *pinningTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if base, err := t.client.baseURL(); err == nil && strings.EqualFold(req.URL.Host, base.Host) {
+		return t.pinned.RoundTrip(req)
+	}
+	return t.other.RoundTrip(req)
+}
+
+func (t *pinningTransport) CloseIdleConnections() {
+	t.pinned.CloseIdleConnections()
+	t.other.CloseIdleConnections()
+}
+
+func (c *Client) verifyPins(cs tls.ConnectionState) error {
+	host := cs.ServerName
+	if base, err := c.baseURL(); err == nil {
+		host = base.Hostname()
+	}
+	if len(cs.PeerCertificates) == 0 {
+		return &PinValidationError{Host: host}
+	}
+	for _, cert := range cs.PeerCertificates {
+		h := spkiHash(cert.RawSubjectPublicKeyInfo)
+		for _, pin := range c.pins {
+			if h == pin {
+				return nil
+			}
+		}
+	}
+	return &PinValidationError{Host: host, Presented: spkiHash(cs.PeerCertificates[0].RawSubjectPublicKeyInfo)}
+}
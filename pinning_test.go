@@ -0,0 +1,66 @@
+package juicewrld
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newPinnedAPI(t *testing.T) (*httptest.Server, string) {
+	t.Helper()
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"id": 1, "name": "Lucid Dreams"})
+	}))
+	// Rejected handshakes are expected; keep them out of the test log.
+	srv.Config.ErrorLog = log.New(io.Discard, "", 0)
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+	return srv, spkiHash(srv.Certificate().RawSubjectPublicKeyInfo)
+}
+
+// pinnedClient trusts srv's generated certificate, as a fresh transport
+// would trust a publicly issued one.
+func pinnedClient(srv *httptest.Server, pins ...string) *Client {
+	return New(srv.URL, WithHTTPClient(srv.Client()), WithCertificatePinning(pins))
+}
+
+func TestCertificatePinning(t *testing.T) {
+	srv, pin := newPinnedAPI(t)
+	const wrong = "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="
+	ctx := context.Background()
+
+	for name, pins := range map[string][]string{
+		"current pin":         {pin},
+		"prefixed pin":        {"sha256/" + pin},
+		"backup after rotate": {wrong, pin},
+	} {
+		if _, err := pinnedClient(srv, pins...).GetSong(ctx, 1); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+
+	_, err := pinnedClient(srv, wrong).GetSong(ctx, 1)
+	var pinErr *PinValidationError
+	if !errors.As(err, &pinErr) {
+		t.Fatalf("mismatched pin: err = %v, want a PinValidationError", err)
+	}
+	if pinErr.Presented != pin || pinErr.Host != "127.0.0.1" {
+		t.Errorf("PinValidationError = %+v, want host 127.0.0.1 presenting %s", pinErr, pin)
+	}
+}
+
+func TestCertificatePinningOnlyAppliesToBaseHost(t *testing.T) {
+	srv, _ := newPinnedAPI(t)
+	other, _ := newPinnedAPI(t)
+	c := pinnedClient(srv, "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=")
+
+	resp, err := c.HTTPClient.Get(other.URL)
+	if err != nil {
+		t.Fatalf("foreign host was pinned: %v", err)
+	}
+	resp.Body.Close()
+}
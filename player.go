@@ -0,0 +1,90 @@
+package juicewrld
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+type PlayerSong struct {
+	ID     int    `json:"id"`
+	Title  string `json:"title"`
+	Artist string `json:"artist"`
+	Album  string `json:"album"`
+	File   string `json:"file"`
+
+	Availability *StreamInfo `json:"availability,omitempty"`
+}
+
+type PaginatedPlayerSongsResponse struct {
+	Results  []PlayerSong `json:"results"`
+	Count    int          `json:"count"`
+	Next     *string      `json:"next"`
+	Previous *string      `json:"previous"`
+}
+
+type PlayerListOptions struct {
+	PageSize          int
+	ProbeAvailability bool
+	ProbeConcurrency  int
+}
+
+func mediaPath(fileURL string) (string, bool) {
+	i := strings.Index(fileURL, "/media/")
+	if i < 0 {
+		return "", false
+	}
+	return fileURL[i+len("/media/"):], true
+}
+
+func (c *Client) GetPlayerSongs(ctx context.Context, page, pageSize int) (PaginatedPlayerSongsResponse, error) {
+	q := url.Values{}
+	if page > 0 {
+		q.Set("page", fmt.Sprintf("%d", page))
+	}
+	if pageSize > 0 {
+		q.Set("page_size", fmt.Sprintf("%d", pageSize))
+	}
+	var out PaginatedPlayerSongsResponse
+	err := c.getPage(ctx, "/juicewrld/player/songs/", q, &out)
+	return out, err
+}
+
+func (c *Client) IteratePlayerSongs(ctx context.Context, opts PlayerListOptions) *Iterator[PlayerSong] {
+	ctx = withDefaultRetryBudget(ctx)
+	q := url.Values{}
+	if opts.PageSize > 0 {
+		q.Set("page_size", strconv.Itoa(opts.PageSize))
+	}
+	pages := NewPaginator[PlayerSong](c, "/juicewrld/player/songs/", q)
+	return newIterator(ctx, func(ctx context.Context) ([]PlayerSong, bool, error) {
+		songs, ok, err := pages.Next(ctx)
+		if ok && opts.ProbeAvailability {
+			c.probePlayerSongs(ctx, songs, opts.ProbeConcurrency)
+		}
+		return songs, ok, err
+	})
+}
+
+func (c *Client) probePlayerSongs(ctx context.Context, songs []PlayerSong, concurrency int) {
+	runConcurrent(ctx, len(songs), concurrency, func(ctx context.Context, i int) error {
+		p, ok := mediaPath(songs[i].File)
+		if !ok || p == "" {
+			songs[i].Availability = &StreamInfo{Status: "invalid_url", SongID: songs[i].ID}
+			return nil
+		}
+		info, ok := c.probeStream(ctx, p)
+		if !ok {
+			info = StreamInfo{Status: "file_not_found", StreamURL: c.downloadURL(p), FilePath: p}
+		}
+		info.SongID = songs[i].ID
+		songs[i].Availability = &info
+		return nil
+	})
+}
+
+func (c *Client) AllPlayerSongs(ctx context.Context) ([]PlayerSong, error) {
+	return c.IteratePlayerSongs(ctx, PlayerListOptions{}).Collect()
+}
@@ -0,0 +1,85 @@
+package juicewrld
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestMediaPathKeepsEncoding(t *testing.T) {
+	tests := []struct {
+		url, path string
+		ok        bool
+	}{
+		{"https://juicewrldapi.com/media/Songs/Lucid%20Dreams.mp3", "Songs/Lucid%20Dreams.mp3", true},
+		{"https://juicewrldapi.com/media/Songs/Robbery.mp3", "Songs/Robbery.mp3", true},
+		{"https://juicewrldapi.com/media/", "", true},
+		{"https://juicewrldapi.com/files/Robbery.mp3", "", false},
+	}
+	for _, tt := range tests {
+		if p, ok := mediaPath(tt.url); p != tt.path || ok != tt.ok {
+			t.Errorf("mediaPath(%q) = %q, %v; want %q, %v", tt.url, p, ok, tt.path, tt.ok)
+		}
+	}
+}
+
+func TestIteratePlayerSongsEmptyCatalog(t *testing.T) {
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"/juicewrld/player/songs/": jsonHandler(map[string]interface{}{"count": 0, "results": []PlayerSong{}}),
+		"/juicewrld/songs/":        jsonHandler(map[string]interface{}{"count": 0, "results": []Song{}}),
+	})
+	ctx := context.Background()
+	c := api.client()
+
+	songs, err := c.AllPlayerSongs(ctx)
+	if err != nil || len(songs) != 0 {
+		t.Errorf("AllPlayerSongs = %v, %v; want none", songs, err)
+	}
+	all, err := c.IterateSongs(ctx, nil).Collect()
+	if err != nil || len(all) != 0 {
+		t.Errorf("IterateSongs = %v, %v; want none", all, err)
+	}
+	if n := api.hitCount("/juicewrld/player/songs/"); n != 1 {
+		t.Errorf("%d player song requests, want 1", n)
+	}
+}
+
+func TestIteratePlayerSongsSinglePageWithProbes(t *testing.T) {
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"/juicewrld/player/songs/": jsonHandler(map[string]interface{}{"count": 3, "next": nil, "results": []PlayerSong{
+			{ID: 1, Title: "Lucid Dreams", File: "https://juicewrldapi.com/media/Songs/Lucid%20Dreams.mp3"},
+			{ID: 2, Title: "Robbery", File: "https://juicewrldapi.com/media/Songs/Robbery.mp3"},
+			{ID: 3, Title: "Broken", File: "not a media link"},
+		}}),
+		"/juicewrld/files/download/": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("path") != "Songs/Lucid%20Dreams.mp3" {
+				statusHandler(http.StatusNotFound)(w, r)
+				return
+			}
+			w.Header().Set("Content-Type", "audio/mpeg")
+			w.WriteHeader(http.StatusPartialContent)
+			w.Write([]byte{0})
+		},
+	})
+	it := api.client().IteratePlayerSongs(context.Background(), PlayerListOptions{ProbeAvailability: true, ProbeConcurrency: 2})
+	songs, err := it.Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		id     int
+		status string
+	}{{1, "success"}, {2, "file_not_found"}, {3, "invalid_url"}}
+	if len(songs) != len(want) {
+		t.Fatalf("got %d songs, want %d", len(songs), len(want))
+	}
+	for i, w := range want {
+		s := songs[i]
+		if s.ID != w.id || s.Availability == nil || s.Availability.Status != w.status || s.Availability.SongID != w.id {
+			t.Errorf("song %d = %+v availability %+v, want id %d status %s", i, s, s.Availability, w.id, w.status)
+		}
+	}
+	if n := api.hitCount("/juicewrld/player/songs/"); n != 1 {
+		t.Errorf("%d player song requests for a single page, want 1", n)
+	}
+}
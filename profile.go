@@ -0,0 +1,154 @@
+package juicewrld
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Profile describes how a deployment of the API differs from the official
+// server, so supporting a new mirror is a data change rather than a fork.
+type Profile struct {
+	Name string
+	// Params maps the canonical query parameter names used by this package
+	// ("search", "page", "page_size", "category", "era", "year", "tags",
+	// "album", "path") to the names the deployment expects. Unlisted
+	// parameters are sent as-is.
+	Params map[string]string
+	// PaginationKeys names the envelope fields; unset keys keep their
+	// defaults. WithPaginationKeys takes precedence.
+	PaginationKeys PaginationKeys
+	// NoTrailingSlash strips the trailing slash from endpoint paths.
+	NoTrailingSlash bool
+	// FlatArrays means list endpoints may return a bare JSON array instead
+	// of a paginated envelope. Without it a bare array is an error.
+	FlatArrays bool
+	// MissingCount means paginated responses omit the total count; Count is
+	// then reported as the number of results in the page.
+	MissingCount bool
+}
+
+// OfficialProfile matches juicewrldapi.com and is the default.
+var OfficialProfile = Profile{
+	Name:           "official",
+	PaginationKeys: DefaultPaginationKeys,
+}
+
+// CommunityMirrorProfile matches the common self-hosted mirror layout:
+// "q" and "per_page" parameters, no trailing slashes and bare arrays.
+var CommunityMirrorProfile = Profile{
+	Name: "community-mirror",
+	Params: map[string]string{
+		"search":    "q",
+		"page_size": "per_page",
+	},
+	NoTrailingSlash: true,
+	FlatArrays:      true,
+	MissingCount:    true,
+}
+
+func WithServerProfile(p Profile) Option {
+	return func(c *Client) {
+		c.profile = p
+	}
+}
+
+func (p Profile) path(endpoint string) string {
+	if p.NoTrailingSlash && len(endpoint) > 1 {
+		return strings.TrimSuffix(endpoint, "/")
+	}
+	return endpoint
+}
+
+func (p Profile) query(q url.Values) url.Values {
+	if len(p.Params) == 0 || len(q) == 0 {
+		return q
+	}
+	out := make(url.Values, len(q))
+	for k, v := range q {
+		if wire, ok := p.Params[k]; ok && wire != "" {
+			k = wire
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// endpointURL builds the absolute URL for an API path and canonical query,
+// applying the server profile. Every request URL is built here.
+func (c *Client) endpointURL(path string, q url.Values) string {
+	return c.profileURL(c.profile, path, q)
+}
+
+func (c *Client) profileURL(p Profile, path string, q url.Values) string {
+	path = p.path(path)
+	query := p.query(q).Encode()
+	base, err := c.baseURL()
+	if err != nil {
+		u := strings.TrimRight(c.BaseURL, "/") + path
+		if query != "" {
+			u += "?" + query
+		}
+		return u
+	}
+	u := *base
+	u.Path = base.ResolveReference(&url.URL{Path: path}).Path
+	u.RawQuery = query
+	return u.String()
+}
+
+// DetectProfile requests one page of songs the official way and, failing
+// that, the mirror way, and returns the shipped profile whose response
+// shape matches: a paginated envelope is the official server, a bare array
+// a community mirror. Pass the result to WithServerProfile when building
+// the client used for real work.
+func (c *Client) DetectProfile(ctx context.Context) (Profile, error) {
+	q := url.Values{"page_size": {"1"}}
+	var raw json.RawMessage
+	err := c.doURL(ctx, http.MethodGet, c.profileURL(OfficialProfile, "/juicewrld/songs/", q), nil, false, &raw)
+	if err == nil {
+		if isBareArray(raw) {
+			return CommunityMirrorProfile, nil
+		}
+		return OfficialProfile, nil
+	}
+	if c.doURL(ctx, http.MethodGet, c.profileURL(CommunityMirrorProfile, "/juicewrld/songs/", q), nil, false, &raw) == nil {
+		return CommunityMirrorProfile, nil
+	}
+	return Profile{}, err
+}
+
+func isBareArray(data []byte) bool {
+	return strings.HasPrefix(strings.TrimSpace(string(data)), "[")
+}
+
+// decodeList accepts a paginated envelope or, for profiles with FlatArrays,
+// a bare array, and decodes it into out as an envelope.
+func (c *Client) decodeList(data []byte, out interface{}) error {
+	if page, ok := out.(*PaginatedSongsResponse); ok {
+		// The page's links carry the profile's parameter names.
+		page.params = c.profile.Params
+	}
+	if isBareArray(data) {
+		if !c.profile.FlatArrays {
+			return fmt.Errorf("paginated response is a bare array and the %q profile does not set FlatArrays", c.profile.Name)
+		}
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return err
+		}
+		buf, err := json.Marshal(map[string]interface{}{"results": items, "count": len(items)})
+		if err != nil {
+			return err
+		}
+		return c.unmarshal(buf, out)
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	return c.decodePage(raw, out)
+}
@@ -0,0 +1,87 @@
+package juicewrld
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+var twoSongs = []map[string]interface{}{{"id": 1, "name": "A"}, {"id": 2, "name": "B"}}
+
+func TestDetectProfile(t *testing.T) {
+	tests := []struct {
+		name   string
+		routes map[string]http.HandlerFunc
+		want   string
+	}{
+		{"envelope", map[string]http.HandlerFunc{
+			"/juicewrld/songs/": jsonHandler(map[string]interface{}{"count": 2, "results": twoSongs}),
+		}, "official"},
+		{"bare array", map[string]http.HandlerFunc{
+			"/juicewrld/songs/": jsonHandler(twoSongs),
+		}, "community-mirror"},
+		{"no trailing slash", map[string]http.HandlerFunc{
+			"/juicewrld/songs": jsonHandler(twoSongs),
+		}, "community-mirror"},
+	}
+	for _, tt := range tests {
+		api := newFakeAPI(t, tt.routes)
+		p, err := api.client().DetectProfile(context.Background())
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if p.Name != tt.want {
+			t.Errorf("%s: detected %q, want %q", tt.name, p.Name, tt.want)
+		}
+	}
+
+	api := newFakeAPI(t, nil)
+	if _, err := api.client().DetectProfile(context.Background()); err == nil {
+		t.Error("DetectProfile succeeded against a server with no song listing")
+	}
+}
+
+func TestFlatArraysGatesBareArrays(t *testing.T) {
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"/juicewrld/songs/": jsonHandler(twoSongs),
+		"/juicewrld/songs":  jsonHandler(twoSongs),
+	})
+	ctx := context.Background()
+
+	if _, err := api.client().ListSongs(ctx, nil); err == nil {
+		t.Error("official profile accepted a bare array")
+	}
+	page, err := api.client(WithServerProfile(CommunityMirrorProfile)).ListSongs(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Results) != 2 || page.Count != 2 {
+		t.Errorf("bare array decoded as %d results, count %d", len(page.Results), page.Count)
+	}
+}
+
+func TestPaginationKeysPrecedence(t *testing.T) {
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"/juicewrld/songs/": jsonHandler(map[string]interface{}{"total": 2, "data": twoSongs}),
+	})
+	keys := PaginationKeys{Results: "data", Count: "total"}
+	profile := OfficialProfile
+	profile.Name = "custom"
+	profile.PaginationKeys = keys
+
+	for name, opts := range map[string][]Option{
+		"keys then profile": {WithPaginationKeys(keys), WithServerProfile(OfficialProfile)},
+		"profile then keys": {WithServerProfile(OfficialProfile), WithPaginationKeys(keys)},
+		"profile keys":      {WithServerProfile(profile)},
+	} {
+		page, err := api.client(opts...).ListSongs(context.Background(), nil)
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if len(page.Results) != 2 || page.Count != 2 {
+			t.Errorf("%s: %d results, count %d", name, len(page.Results), page.Count)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package juicewrld
+
+import (
+	"context"
+	"slices"
+)
+
+// SongsQueryBuilder is a chainable front end for SongFilter. Each method
+// returns a modified copy, so a partially built query can be reused as a
+// template.
+type SongsQueryBuilder struct {
+	client *Client
+	filter SongFilter
+}
+
+// Songs starts a song query:
+//
+//	songs, err := c.Songs().Category("unreleased").Search("lucid").All(ctx)
+func (c *Client) Songs() SongsQueryBuilder {
+	return SongsQueryBuilder{client: c}
+}
+
+func (b SongsQueryBuilder) Category(category string) SongsQueryBuilder {
+	b.filter.Category = category
+	return b
+}
+
+// Era adds an era to the query. Calling it more than once matches songs
+// from any of the given eras.
+func (b SongsQueryBuilder) Era(era string) SongsQueryBuilder {
+	b.filter.Eras = append(slices.Clip(b.filter.Eras), era)
+	return b
+}
+
+// ExcludeCategory leaves songs in category out of the results; see
+// SongFilter.ExcludeCategories.
+func (b SongsQueryBuilder) ExcludeCategory(category string) SongsQueryBuilder {
+	b.filter.ExcludeCategories = append(slices.Clip(b.filter.ExcludeCategories), category)
+	return b
+}
+
+// IncludeRemoved keeps songs taken down from the catalog in the results.
+func (b SongsQueryBuilder) IncludeRemoved() SongsQueryBuilder {
+	b.filter.IncludeRemoved = true
+	return b
+}
+
+func (b SongsQueryBuilder) Search(query string) SongsQueryBuilder {
+	b.filter.Search = query
+	return b
+}
+
+func (b SongsQueryBuilder) PageSize(n int) SongsQueryBuilder {
+	b.filter.PageSize = n
+	return b
+}
+
+// Filter returns the SongFilter the builder has accumulated.
+func (b SongsQueryBuilder) Filter() SongFilter {
+	f := b.filter
+	f.Eras = slices.Clone(f.Eras)
+	f.ExcludeCategories = slices.Clone(f.ExcludeCategories)
+	return f
+}
+
+// Page fetches page n of the results.
+func (b SongsQueryBuilder) Page(ctx context.Context, n int) (PaginatedSongsResponse, error) {
+	f := b.Filter()
+	f.Page = n
+	return b.client.ListSongs(ctx, &f)
+}
+
+// All fetches every matching song across all pages.
+func (b SongsQueryBuilder) All(ctx context.Context) (Songs, error) {
+	f := b.Filter()
+	return b.client.GetAllSongs(ctx, &f)
+}
+
+// Iterate walks the results one song at a time, fetching pages on demand.
+func (b SongsQueryBuilder) Iterate(ctx context.Context) *Iterator[Song] {
+	f := b.Filter()
+	return b.client.IterateSongs(ctx, &f)
+}
@@ -0,0 +1,75 @@
+package juicewrld
+
+import (
+	"net/url"
+	"strings"
+	"unicode"
+)
+
+// WithQueryNormalization controls the cleanup of text copied from chat
+// apps before it is sent as a search parameter: accents are composed (see
+// composeLatin), curly quotes and long dashes are folded to their ASCII
+// forms, non-breaking and other Unicode spaces become plain spaces,
+// zero-width characters are dropped and runs of whitespace collapse. It is
+// on by default and applies to ListSongs, GetSongs, SearchSongs, the
+// search of BrowseFiles and the other song listings that take a
+// SongFilter. File paths only have their accents composed, so names that
+// really contain curly quotes or dashes stay reachable.
+func WithQueryNormalization(enabled bool) Option {
+	return func(c *Client) { c.queryNormalization = enabled }
+}
+
+var queryTextFolds = map[rune]rune{
+	'‘': '\'', '’': '\'', '‚': '\'', '‛': '\'', '′': '\'', '＇': '\'',
+	'“': '"', '”': '"', '„': '"', '‟': '"', '″': '"', '＂': '"',
+	'‐': '-', '‑': '-', '‒': '-', '–': '-', '—': '-', '―': '-', '−': '-', '﹣': '-', '－': '-',
+}
+
+// normalizeQueryText applies the character folding of
+// WithQueryNormalization without touching whitespace runs.
+func normalizeQueryText(s string) string {
+	s = composeLatin(s)
+	return strings.Map(func(r rune) rune {
+		if f, ok := queryTextFolds[r]; ok {
+			return f
+		}
+		switch r {
+		case '\u200B', '\u200C', '\u200D', '\u2060', '\uFEFF':
+			return -1
+		}
+		if unicode.Is(unicode.Zs, r) {
+			return ' '
+		}
+		return r
+	}, s)
+}
+
+// normalizeSearch prepares a search query for the wire.
+func (c *Client) normalizeSearch(q string) string {
+	if c.queryNormalization {
+		q = normalizeQueryText(q)
+	}
+	return NormalizeSearchQuery(q)
+}
+
+// normalizePath composes accents in a file path and changes nothing else,
+// so paths returned by BrowseFiles round-trip unchanged.
+func (c *Client) normalizePath(p string) string {
+	if !c.queryNormalization {
+		return p
+	}
+	return composeLatin(p)
+}
+
+// songFilterQuery is filter.ToQueryValues with the search normalized.
+func (c *Client) songFilterQuery(filter *SongFilter) url.Values {
+	q := filter.ToQueryValues()
+	if s := q.Get("search"); s != "" {
+		if s = c.normalizeSearch(s); s != "" {
+			q.Set("search", s)
+		} else {
+			q.Del("search")
+		}
+	}
+	return q
+}
@@ -0,0 +1,98 @@
+package juicewrld
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// queryRecorder answers every path with an empty listing and records the
+// decoded query parameters it was sent.
+type queryRecorder struct {
+	mu   sync.Mutex
+	last map[string]string
+}
+
+func (q *queryRecorder) handle(w http.ResponseWriter, r *http.Request) {
+	q.mu.Lock()
+	q.last = map[string]string{}
+	for k := range r.URL.Query() {
+		q.last[k] = r.URL.Query().Get(k)
+	}
+	q.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"count": 0, "results": []Song{}, "items": []FileInfo{}})
+}
+
+func (q *queryRecorder) param(k string) string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.last[k]
+}
+
+func TestQueryNormalizationSendsIdenticalRequests(t *testing.T) {
+	rec := &queryRecorder{}
+	srv := httptest.NewServer(http.HandlerFunc(rec.handle))
+	defer srv.Close()
+	c := New(srv.URL)
+	ctx := context.Background()
+
+	search := func(q string) string {
+		if _, err := c.GetSongs(ctx, 1, nil, nil, &q, 0); err != nil {
+			t.Fatal(err)
+		}
+		return rec.param("search")
+	}
+	browse := func(p string) string {
+		if _, err := c.BrowseFiles(ctx, p, nil); err != nil {
+			t.Fatal(err)
+		}
+		return rec.param("path")
+	}
+
+	const composed, decomposed = "Caf\u00e9 Lean", "Cafe\u0301 Lean"
+	if a, b := search(composed), search(decomposed); a != b || a != composed {
+		t.Errorf("search sent %q and %q for composed and decomposed é, want %q for both", a, b, composed)
+	}
+	if a, b := browse(composed), browse(decomposed); a != b || a != composed {
+		t.Errorf("path sent %q and %q for composed and decomposed é, want %q for both", a, b, composed)
+	}
+
+	if a, b := search("Don’t Go"), search("Don't Go"); a != b {
+		t.Errorf("search sent %q and %q for curly and straight quotes, want the same", a, b)
+	}
+	// Paths keep curly quotes and dashes: they can be part of a real name.
+	for _, p := range []string{"Snippets/Don’t Go", "Sessions/2018 – Chicago"} {
+		if got := browse(p); got != p {
+			t.Errorf("path %q sent as %q", p, got)
+		}
+	}
+
+	c = New(srv.URL, WithQueryNormalization(false))
+	if got := browse(decomposed); got != decomposed {
+		t.Errorf("normalization off: path sent as %q, want %q", got, decomposed)
+	}
+}
+
+func TestComposeLatin(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"Cafe\u0301", "Caf\u00e9"},
+		{"Caf\u00e9", "Caf\u00e9"},
+		{"Se\u0301ance Pin\u0303a", "S\u00e9ance Pi\u00f1a"},
+		// Two marks compose in two steps when they come in table order.
+		{"e\u0302\u0303", "\u1ec5"},
+		// No canonical reordering: the other order stays half composed.
+		{"e\u0303\u0302", "\u1ebd\u0302"},
+		// Non-Latin text and stray marks pass through.
+		{"\u0436\u0301", "\u0436\u0301"},
+		{"\u0301x", "\u0301x"},
+	}
+	for _, tt := range tests {
+		if got := composeLatin(tt.in); got != tt.want {
+			t.Errorf("composeLatin(%+q) = %+q, want %+q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package juicewrld
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+type SongWithRelated struct {
+	Song
+	RelatedByProducer Songs `json:"related_by_producer"`
+	RelatedByEra      Songs `json:"related_by_era"`
+}
+
+func splitCredits(s string) []string {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == '&' || r == ';' || r == '/'
+	})
+	var out []string
+	seen := map[string]bool{}
+	for _, f := range fields {
+		f = strings.TrimSpace(f)
+		key := foldString(f)
+		if f == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, f)
+	}
+	return out
+}
+
+func (c *Client) GetSongWithRelated(ctx context.Context, songID int, limit int) (SongWithRelated, error) {
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	ctx = withDefaultRetryBudget(ctx)
+	song, err := c.GetSong(ctx, songID)
+	if err != nil {
+		return SongWithRelated{}, err
+	}
+	out := SongWithRelated{Song: song}
+
+	producers := splitCredits(song.Producers)
+	byProducer := make([]Songs, len(producers))
+	tasks := len(producers)
+	if song.Era.ID != 0 {
+		tasks++
+	}
+	err = runConcurrent(ctx, tasks, defaultConcurrency, func(ctx context.Context, i int) error {
+		if i == len(producers) {
+			page, err := c.ListSongs(ctx, &SongFilter{Era: strconv.Itoa(song.Era.ID), PageSize: limit + 1})
+			if err != nil {
+				return err
+			}
+			out.RelatedByEra = excludeSong(page.Results, songID, limit)
+			return nil
+		}
+		res, err := c.SearchSongs(ctx, producers[i], nil, nil, nil, limit+1, 0)
+		if err != nil {
+			return err
+		}
+		needle := foldString(producers[i])
+		for _, s := range res.Songs {
+			if containsFolded(s.Producers, needle) {
+				byProducer[i] = append(byProducer[i], s)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return SongWithRelated{}, err
+	}
+	out.RelatedByProducer = excludeSong(mergeUniqueSongs(byProducer...), songID, limit)
+	return out, nil
+}
+
+func excludeSong(songs Songs, songID int, limit int) Songs {
+	out := make(Songs, 0, min(len(songs), limit))
+	for _, s := range songs {
+		if s.ID == songID {
+			continue
+		}
+		if len(out) == limit {
+			break
+		}
+		out = append(out, s)
+	}
+	return out
+}
@@ -0,0 +1,81 @@
+package juicewrld
+
+import (
+	"context"
+	"strings"
+)
+
+// WithRemovedStatuses treats songs whose status is one of statuses,
+// compared case-insensitively, as taken down, for deployments that mark
+// takedowns with a status rather than the removed flag. The client sets
+// Song.Removed on such songs as it returns them.
+func WithRemovedStatuses(statuses ...string) Option {
+	return func(c *Client) {
+		c.removedStatuses = make(map[string]bool, len(statuses))
+		for _, s := range statuses {
+			c.removedStatuses[strings.ToLower(strings.TrimSpace(s))] = true
+		}
+	}
+}
+
+// IsRemoved reports whether the song is marked as taken down: the server's
+// removed flag, or a status listed with WithRemovedStatuses. Removed songs
+// are still returned by GetSong so they can be shown as such, but song
+// listings and searches drop them unless a SongFilter sets IncludeRemoved.
+func (s Song) IsRemoved() bool {
+	return s.Removed
+}
+
+// markRemoved sets Removed on a song whose status the client was told
+// marks a takedown, and reports whether it changed the song.
+func (c *Client) markRemoved(s *Song) bool {
+	if s.Removed || !c.removedStatuses[strings.ToLower(strings.TrimSpace(s.Status))] {
+		return false
+	}
+	s.Removed = true
+	return true
+}
+
+// exclude is filter.exclude after marking removed songs. songs is copied
+// before it is marked, as it may be shared with a cache.
+func (c *Client) exclude(filter *SongFilter, songs Songs) Songs {
+	for i := range songs {
+		if s := songs[i]; c.markRemoved(&s) {
+			songs = append(Songs(nil), songs...)
+			for j := i; j < len(songs); j++ {
+				c.markRemoved(&songs[j])
+			}
+			break
+		}
+	}
+	return filter.exclude(songs)
+}
+
+// excludePage applies exclude to a page and records how many songs it
+// dropped. Count is left as the server's total for the whole listing, as
+// the client only sees the songs dropped from this page.
+func (c *Client) excludePage(filter *SongFilter, page *PaginatedSongsResponse) {
+	n := len(page.Results)
+	page.Results = c.exclude(filter, page.Results)
+	page.dropped += n - len(page.Results)
+}
+
+// Dropped returns how many songs the client removed from this page, as
+// removed songs or through SongFilter.ExcludeCategories. Count still
+// includes them.
+func (r PaginatedSongsResponse) Dropped() int {
+	return r.dropped
+}
+
+// GetRemovedSongs returns every song marked as removed. The API has no
+// filter for them, so this walks the whole catalog.
+func (c *Client) GetRemovedSongs(ctx context.Context) (Songs, error) {
+	all, err := c.GetAllSongs(ctx, &SongFilter{IncludeRemoved: true})
+	var out Songs
+	for _, s := range all {
+		if s.IsRemoved() {
+			out = append(out, s)
+		}
+	}
+	return out, err
+}
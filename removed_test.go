@@ -0,0 +1,192 @@
+package juicewrld
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// newRemovedSongsAPI serves a three-song catalog, in one page, where song 2
+// carries the removed flag and song 3 only a "hidden" status. The listing
+// ignores every filter, like a server with no notion of takedowns.
+func newRemovedSongsAPI(t *testing.T) *fakeAPI {
+	t.Helper()
+	songs := []map[string]interface{}{
+		{"id": 1, "name": "Lucid Dreams", "era": map[string]interface{}{"id": 1, "name": "GBGR"}, "credited_artists": "Juice WRLD"},
+		{"id": 2, "name": "Taken Down", "era": map[string]interface{}{"id": 1, "name": "GBGR"}, "credited_artists": "Juice WRLD", "removed": true},
+		{"id": 3, "name": "Hidden", "era": map[string]interface{}{"id": 1, "name": "GBGR"}, "credited_artists": "Juice WRLD", "status": "Hidden"},
+	}
+	return newFakeAPI(t, map[string]http.HandlerFunc{
+		"/juicewrld/songs/":     jsonHandler(map[string]interface{}{"count": len(songs), "results": songs}),
+		"/juicewrld/songs/2/":   jsonHandler(songs[1]),
+		"/juicewrld/eras/1/":    jsonHandler(map[string]interface{}{"id": 1, "name": "GBGR"}),
+		"/juicewrld/artists/1/": jsonHandler(map[string]interface{}{"id": 1, "name": "Juice WRLD"}),
+	})
+}
+
+// newPagedRemovedSongsAPI serves six songs in three pages of two, with
+// both songs on the middle page removed.
+func newPagedRemovedSongsAPI(t *testing.T) *fakeAPI {
+	t.Helper()
+	var api *fakeAPI
+	api = newFakeAPI(t, map[string]http.HandlerFunc{
+		"/juicewrld/songs/": func(w http.ResponseWriter, r *http.Request) {
+			page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+			page = max(page, 1)
+			var next interface{}
+			if page < 3 {
+				next = fmt.Sprintf("%s/juicewrld/songs/?page=%d", api.URL, page+1)
+			}
+			results := []map[string]interface{}{
+				{"id": 2*page - 1, "name": "Song", "removed": page == 2},
+				{"id": 2 * page, "name": "Song", "removed": page == 2},
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{"count": 6, "next": next, "results": results})
+		},
+	})
+	return api
+}
+
+func ids(songs []Song) []int {
+	var out []int
+	for _, s := range songs {
+		out = append(out, s.ID)
+	}
+	return out
+}
+
+func TestRemovedSongsDroppedFromListings(t *testing.T) {
+	api := newRemovedSongsAPI(t)
+	ctx := context.Background()
+	c := api.client()
+	want := []int{1, 3}
+
+	page, err := c.GetSongs(ctx, 1, nil, nil, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ids(page.Results), want) || page.Count != 3 || page.Dropped() != 1 {
+		t.Errorf("GetSongs = %v count %d dropped %d, want %v count 3 dropped 1", ids(page.Results), page.Count, page.Dropped(), want)
+	}
+	if n := page.TotalPages(3); n != 1 {
+		t.Errorf("TotalPages(3) = %d, want the server's single page", n)
+	}
+
+	listed, err := c.ListSongs(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ids(listed.Results), want) || listed.Count != 3 {
+		t.Errorf("ListSongs = %v count %d", ids(listed.Results), listed.Count)
+	}
+	all, err := c.ListSongs(ctx, &SongFilter{IncludeRemoved: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all.Results) != 3 || all.Count != 3 {
+		t.Errorf("IncludeRemoved = %v count %d, want all three", ids(all.Results), all.Count)
+	}
+
+	found, err := c.SearchSongs(ctx, "a", nil, nil, nil, 10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ids(found.Songs), want) || found.Total != 3 {
+		t.Errorf("SearchSongs = %v total %d", ids(found.Songs), found.Total)
+	}
+
+	era, err := c.GetEraWithSongs(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ids(era.Songs), want) {
+		t.Errorf("GetEraWithSongs = %v", ids(era.Songs))
+	}
+	if n, err := era.SongCount(ctx, c); err != nil || n != 3 {
+		t.Errorf("SongCount = %d, %v; want the server's count of 3", n, err)
+	}
+
+	artist, err := c.GetArtistSongs(ctx, 1, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ids(artist.Results), want) {
+		t.Errorf("GetArtistSongs = %v", ids(artist.Results))
+	}
+
+	song, err := c.GetSong(ctx, 2)
+	if err != nil || !song.IsRemoved() {
+		t.Errorf("GetSong(2) = %+v, %v; want the record marked removed", song, err)
+	}
+	removed, err := c.GetRemovedSongs(ctx)
+	if err != nil || !reflect.DeepEqual(ids(removed), []int{2}) {
+		t.Errorf("GetRemovedSongs = %v, %v", ids(removed), err)
+	}
+}
+
+func TestWithRemovedStatuses(t *testing.T) {
+	api := newRemovedSongsAPI(t)
+	ctx := context.Background()
+	c := api.client(WithRemovedStatuses("hidden"))
+
+	page, err := c.GetSongs(ctx, 1, nil, nil, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ids(page.Results), []int{1}) || page.Dropped() != 2 {
+		t.Errorf("GetSongs = %v dropped %d, want only song 1", ids(page.Results), page.Dropped())
+	}
+	all, err := c.ListSongs(ctx, &SongFilter{IncludeRemoved: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range all.Results {
+		if s.IsRemoved() != (s.ID != 1) {
+			t.Errorf("song %d IsRemoved = %v", s.ID, s.IsRemoved())
+		}
+	}
+	removed, err := c.GetRemovedSongs(ctx)
+	if err != nil || !reflect.DeepEqual(ids(removed), []int{2, 3}) {
+		t.Errorf("GetRemovedSongs = %v, %v", ids(removed), err)
+	}
+}
+
+func TestIterateSongsSkipsFullyRemovedPage(t *testing.T) {
+	api := newPagedRemovedSongsAPI(t)
+	songs, err := api.client().IterateSongs(context.Background(), nil).Collect()
+	if err != nil || !reflect.DeepEqual(ids(songs), []int{1, 2, 5, 6}) {
+		t.Errorf("IterateSongs = %v, %v; want the pages around the removed one", ids(songs), err)
+	}
+	if n := api.hitCount("/juicewrld/songs/"); n != 3 {
+		t.Errorf("%d page requests, want 3", n)
+	}
+}
+
+func TestRemovedSongsLeaveServerCount(t *testing.T) {
+	api := newPagedRemovedSongsAPI(t)
+	ctx := context.Background()
+	c := api.client()
+	for page, want := range [][]int{{1, 2}, nil, {5, 6}} {
+		out, err := c.ListSongs(ctx, &SongFilter{Page: page + 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		dropped := 0
+		if want == nil {
+			dropped = 2
+		}
+		if !reflect.DeepEqual(ids(out.Results), want) || out.Count != 6 || out.Dropped() != dropped {
+			t.Errorf("page %d = %v count %d dropped %d, want %v count 6 dropped %d",
+				page+1, ids(out.Results), out.Count, out.Dropped(), want, dropped)
+		}
+		if n := out.TotalPages(2); n != 3 {
+			t.Errorf("page %d TotalPages(2) = %d, want 3", page+1, n)
+		}
+		if n := out.TotalPages(0); out.Next != nil && n != 3 {
+			t.Errorf("page %d TotalPages(0) = %d, want 3 from the server's page length", page+1, n)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package juicewrld
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	defaultRetryBaseDelay = 500 * time.Millisecond
+	defaultRetryMaxDelay  = 30 * time.Second
+
+	defaultBudgetRetries = 10
+	defaultBudgetDelay   = 30 * time.Second
+)
+
+type JitterStrategy int
+
+const (
+	FullJitter JitterStrategy = iota
+	EqualJitter
+	NoJitter
+)
+
+func WithRetryJitter(strategy JitterStrategy) Option {
+	return func(c *Client) {
+		c.jitter = strategy
+	}
+}
+
+// WithBackoff replaces the exponential backoff between retries with
+// backoff(attempt), where attempt is 1 for the first retry. The returned
+// delay is used as-is: jitter and the 30s cap only apply to the default
+// schedule, and negative delays count as zero. WithRetry still sets how
+// many retries are made, and retry budgets still apply.
+func WithBackoff(backoff func(attempt int) time.Duration) Option {
+	return func(c *Client) {
+		c.backoff = backoff
+	}
+}
+
+func (c *Client) applyJitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return d
+	}
+	switch c.jitter {
+	case NoJitter:
+		return d
+	case EqualJitter:
+		half := d / 2
+		return half + time.Duration(c.randInt63n(int64(d-half)+1))
+	default:
+		return time.Duration(c.randInt63n(int64(d) + 1))
+	}
+}
+
+type retryBudgetKey struct{}
+
+type retryBudget struct {
+	mu         sync.Mutex
+	retries    int
+	delay      time.Duration
+	maxRetries int
+	maxDelay   time.Duration
+}
+
+// WithRetryBudget bounds the retries made beneath ctx, by any request or
+// helper, to maxTotalRetries in total and their combined backoff to
+// maxTotalDelay. A maxTotalRetries of zero or less allows no retries; a
+// maxTotalDelay of zero or less leaves the delay unbounded. Once the budget
+// is spent, failures are returned wrapped in *BudgetExhaustedError.
+func WithRetryBudget(ctx context.Context, maxTotalRetries int, maxTotalDelay time.Duration) context.Context {
+	return context.WithValue(ctx, retryBudgetKey{}, &retryBudget{
+		maxRetries: maxTotalRetries,
+		maxDelay:   maxTotalDelay,
+	})
+}
+
+func retryBudgetFrom(ctx context.Context) *retryBudget {
+	b, _ := ctx.Value(retryBudgetKey{}).(*retryBudget)
+	return b
+}
+
+func withDefaultRetryBudget(ctx context.Context) context.Context {
+	if retryBudgetFrom(ctx) != nil {
+		return ctx
+	}
+	return WithRetryBudget(ctx, defaultBudgetRetries, defaultBudgetDelay)
+}
+
+func (b *retryBudget) consume(delay time.Duration) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.retries >= b.maxRetries {
+		return false
+	}
+	if b.maxDelay > 0 && b.delay+delay > b.maxDelay {
+		return false
+	}
+	b.retries++
+	b.delay += delay
+	return true
+}
+
+func (c *Client) retryDelay(ctx context.Context, method string, attempt int, err error) (time.Duration, bool) {
+	if attempt >= c.maxRetries || ctx.Err() != nil || !isRetryable(method, err) {
+		return 0, false
+	}
+	if c.backoff != nil {
+		return max(c.backoff(attempt+1), 0), true
+	}
+	base := c.retryBaseDelay
+	if base <= 0 {
+		base = defaultRetryBaseDelay
+	}
+	delay := base << attempt
+	if delay <= 0 || delay > defaultRetryMaxDelay {
+		delay = defaultRetryMaxDelay
+	}
+	return c.applyJitter(delay), true
+}
+
+// spendRetry charges one retry with no delay to the budget in ctx, if any,
+// for retries made outside do such as connection and part retries.
+func spendRetry(ctx context.Context) bool {
+	b := retryBudgetFrom(ctx)
+	return b == nil || b.consume(0)
+}
+
+// sendRetrying is HTTPClient.Do for requests built outside do, such as
+// probes, downloads and streams: failures and retryable statuses are
+// retried as do retries them, charged to the retry budget in req's context.
+// A response that is not retried is returned unread for the caller to
+// check.
+func (c *Client) sendRetrying(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		resp, err := c.HTTPClient.Do(req)
+		cause := err
+		if err == nil {
+			switch {
+			case resp.StatusCode == http.StatusTooManyRequests:
+				cause = &RateLimitError{APIError{StatusCode: resp.StatusCode}}
+			case resp.StatusCode >= 500:
+				cause = &APIError{StatusCode: resp.StatusCode}
+			default:
+				return resp, nil
+			}
+		}
+		delay, ok := c.retryDelay(ctx, req.Method, attempt, cause)
+		if !ok || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+			return resp, err
+		}
+		retry := req.Clone(withAttempt(ctx, attempt+1))
+		if req.GetBody != nil {
+			body, berr := req.GetBody()
+			if berr != nil {
+				return resp, err
+			}
+			retry.Body = body
+		}
+		if resp != nil {
+			cause = checkResponse(resp)
+			resp.Body.Close()
+		}
+		if err := c.waitRetry(ctx, delay, cause); err != nil {
+			return nil, err
+		}
+		req = retry
+	}
+}
+
+func (c *Client) waitRetry(ctx context.Context, delay time.Duration, cause error) error {
+	if b := retryBudgetFrom(ctx); b != nil && !b.consume(delay) {
+		return &BudgetExhaustedError{Err: cause}
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-c.after(delay):
+		return nil
+	}
+}
+
+func isRetryable(method string, err error) bool {
+	if errors.Is(err, ErrOffline) {
+		return false
+	}
+	var rl *RateLimitError
+	if errors.As(err, &rl) {
+		return true
+	}
+	if !isIdempotent(method) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= 500
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
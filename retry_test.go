@@ -0,0 +1,138 @@
+package juicewrld
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRetryBudgetSharedAcrossSubRequests(t *testing.T) {
+	paths := make([]string, 20)
+	for i := range paths {
+		paths[i] = fmt.Sprintf("file-%d.mp3", i)
+	}
+	tests := []struct {
+		name string
+		run  func(ctx context.Context, c *Client) error
+	}{
+		{"probes", func(ctx context.Context, c *Client) error {
+			_, _, err := c.ValidatePaths(ctx, paths)
+			return err
+		}},
+		{"downloads", func(ctx context.Context, c *Client) error {
+			dir := t.TempDir()
+			tasks := make([]DownloadTask, len(paths))
+			for i, p := range paths {
+				tasks[i] = DownloadTask{RemotePath: p, LocalPath: filepath.Join(dir, p)}
+			}
+			_, err := c.DownloadFiles(ctx, tasks, 4)
+			return err
+		}},
+		{"api requests", func(ctx context.Context, c *Client) error {
+			var errs []error
+			for i := range paths {
+				_, err := c.GetSong(ctx, i+1)
+				errs = append(errs, err)
+			}
+			return errors.Join(errs...)
+		}},
+	}
+	for _, tt := range tests {
+		for _, budget := range []int{5, 0} {
+			t.Run(fmt.Sprintf("%s/budget %d", tt.name, budget), func(t *testing.T) {
+				api := newFakeAPI(t, nil)
+				api.fallback = statusHandler(http.StatusServiceUnavailable)
+				c := api.client(WithRetry(3, time.Millisecond))
+				ctx := WithRetryBudget(context.Background(), budget, 0)
+
+				err := tt.run(ctx, c)
+				var exhausted *BudgetExhaustedError
+				if !errors.As(err, &exhausted) {
+					t.Errorf("err = %v, want a BudgetExhaustedError", err)
+				}
+				total := 0
+				api.mu.Lock()
+				for _, n := range api.hits {
+					total += n
+				}
+				api.mu.Unlock()
+				if total != len(paths)+budget {
+					t.Errorf("%d requests, want %d first attempts and %d retries", total, len(paths), budget)
+				}
+			})
+		}
+	}
+}
+
+// delayRecordingClock returns from After at once and records the delays
+// it was asked to wait.
+type delayRecordingClock struct {
+	realClock
+	mu     sync.Mutex
+	delays []time.Duration
+}
+
+func (c *delayRecordingClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	c.delays = append(c.delays, d)
+	c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	ch <- time.Now()
+	return ch
+}
+
+func TestRetryJitter(t *testing.T) {
+	const retries, base = 6, 100 * time.Millisecond
+	delays := func(t *testing.T, opts ...Option) []time.Duration {
+		t.Helper()
+		api := newFakeAPI(t, nil)
+		api.fallback = statusHandler(http.StatusServiceUnavailable)
+		clock := &delayRecordingClock{}
+		opts = append([]Option{WithRetry(retries, base), WithClock(clock), WithRandSeed(42)}, opts...)
+		if _, err := api.client(opts...).GetSong(context.Background(), 1); err == nil {
+			t.Fatal("GetSong succeeded against a failing server")
+		}
+		if len(clock.delays) != retries {
+			t.Fatalf("%d retry waits, want %d", len(clock.delays), retries)
+		}
+		return clock.delays
+	}
+	tests := []struct {
+		name     string
+		strategy JitterStrategy
+		lo, hi   func(d time.Duration) time.Duration
+	}{
+		{"full", FullJitter, func(time.Duration) time.Duration { return 0 }, func(d time.Duration) time.Duration { return d }},
+		{"equal", EqualJitter, func(d time.Duration) time.Duration { return d / 2 }, func(d time.Duration) time.Duration { return d }},
+		{"none", NoJitter, func(d time.Duration) time.Duration { return d }, func(d time.Duration) time.Duration { return d }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := delays(t, WithRetryJitter(tt.strategy))
+			jittered := false
+			for i, delay := range got {
+				d := base << i
+				if delay < tt.lo(d) || delay > tt.hi(d) {
+					t.Errorf("retry %d waited %v, want %v to %v", i+1, delay, tt.lo(d), tt.hi(d))
+				}
+				jittered = jittered || delay != d
+			}
+			if jittered != (tt.strategy != NoJitter) {
+				t.Errorf("delays %v: jittered = %v", got, jittered)
+			}
+			if again := delays(t, WithRetryJitter(tt.strategy)); !reflect.DeepEqual(again, got) {
+				t.Errorf("same seed gave %v, then %v", got, again)
+			}
+		})
+	}
+
+	if def, full := delays(t), delays(t, WithRetryJitter(FullJitter)); !reflect.DeepEqual(def, full) {
+		t.Errorf("default delays %v, want full jitter's %v", def, full)
+	}
+}
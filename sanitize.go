@@ -0,0 +1,133 @@
+package juicewrld
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// WithSanitizeStrings cleans the string fields of decoded responses (Song,
+// Album, FileInfo and the envelopes around them) for display and storage:
+// invalid UTF-8 becomes U+FFFD, carriage returns become newlines, other
+// control characters except tab and newline are dropped, and surrounding
+// whitespace is trimmed. Path fields (JSON names "path" or ending in
+// "_path") only get the UTF-8 repair, so they still address the same file.
+func WithSanitizeStrings() Option {
+	return func(c *Client) {
+		c.sanitizeStrings = true
+	}
+}
+
+// unmarshal decodes a response body into out, applying the
+// WithSanitizeStrings pass when it is enabled.
+func (c *Client) unmarshal(data []byte, out interface{}) error {
+	if err := json.Unmarshal(data, out); err != nil {
+		return err
+	}
+	if c.sanitizeStrings {
+		sanitizeValue(reflect.ValueOf(out), false)
+	}
+	return nil
+}
+
+// decode is unmarshal for a response body that is not buffered.
+func (c *Client) decode(r io.Reader, out interface{}) error {
+	if err := json.NewDecoder(r).Decode(out); err != nil {
+		return err
+	}
+	if c.sanitizeStrings {
+		sanitizeValue(reflect.ValueOf(out), false)
+	}
+	return nil
+}
+
+func sanitizeValue(v reflect.Value, isPath bool) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+		if v.Kind() == reflect.Interface {
+			// Values held in an interface are not addressable; sanitize a
+			// copy and store it back.
+			elem := reflect.New(v.Elem().Type()).Elem()
+			elem.Set(v.Elem())
+			sanitizeValue(elem, isPath)
+			if v.CanSet() {
+				v.Set(elem)
+			}
+			return
+		}
+		sanitizeValue(v.Elem(), isPath)
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			if f := v.Field(i); f.CanSet() {
+				sanitizeValue(f, isPathField(t.Field(i)))
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if k := v.Type().Elem().Kind(); k <= reflect.Complex128 {
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			sanitizeValue(v.Index(i), isPath)
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			elem.Set(iter.Value())
+			sanitizeValue(elem, isPath || isPathKey(iter.Key()))
+			v.SetMapIndex(iter.Key(), elem)
+		}
+	case reflect.String:
+		if !v.CanSet() {
+			return
+		}
+		s := v.String()
+		if isPath {
+			if !utf8.ValidString(s) {
+				v.SetString(strings.ToValidUTF8(s, "\uFFFD"))
+			}
+			return
+		}
+		if clean := sanitizeString(s); clean != s {
+			v.SetString(clean)
+		}
+	}
+}
+
+// sanitizeString is the WithSanitizeStrings cleanup for non-path text.
+func sanitizeString(s string) string {
+	s = strings.ToValidUTF8(s, "\uFFFD")
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case r == '\r':
+			return '\n'
+		case r == '\t', r == '\n':
+			return r
+		case unicode.IsControl(r):
+			return -1
+		}
+		return r
+	}, s)
+	return strings.TrimSpace(s)
+}
+
+func isPathField(f reflect.StructField) bool {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	return isPathName(name)
+}
+
+func isPathKey(k reflect.Value) bool {
+	return k.Kind() == reflect.String && isPathName(k.String())
+}
+
+func isPathName(name string) bool {
+	return name == "path" || strings.HasSuffix(name, "_path")
+}
@@ -0,0 +1,68 @@
+package juicewrld
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestSanitizeStringsCleansDecodedResponses(t *testing.T) {
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"/juicewrld/songs/1/": jsonHandler(map[string]interface{}{
+			"id":           1,
+			"name":         "  Lucid\u0007 Dreams\u0000 ",
+			"notes":        "line one\r\nline two\rline three\twith tab",
+			"track_titles": []string{"\u001bLucid Dreams"},
+		}),
+		"/juicewrld/files/info/": jsonHandler(map[string]interface{}{
+			"name": " a.mp3\u0001",
+			"path": "Dir/ a.mp3\u0001",
+		}),
+	})
+	ctx := context.Background()
+
+	c := api.client(WithSanitizeStrings())
+	song, err := c.GetSong(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if song.Name != "Lucid Dreams" {
+		t.Errorf("Name = %q", song.Name)
+	}
+	if song.Notes != "line one\nline two\nline three\twith tab" {
+		t.Errorf("Notes = %q", song.Notes)
+	}
+	if len(song.TrackTitles) != 1 || song.TrackTitles[0] != "Lucid Dreams" {
+		t.Errorf("TrackTitles = %q", song.TrackTitles)
+	}
+	info, err := c.GetFileInfo(ctx, "Dir/ a.mp3\u0001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Name != "a.mp3" || info.Path != "Dir/ a.mp3\u0001" {
+		t.Errorf("FileInfo name %q path %q; the path must be left addressable", info.Name, info.Path)
+	}
+
+	raw, err := api.client().GetSong(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if raw.Name != "  Lucid\u0007 Dreams\u0000 " {
+		t.Errorf("without the option Name = %q, want it untouched", raw.Name)
+	}
+}
+
+func TestSanitizeValueRepairsInvalidUTF8(t *testing.T) {
+	type doc struct {
+		Title string            `json:"title"`
+		Path  string            `json:"cover_art_path"`
+		Meta  map[string]string `json:"meta"`
+	}
+	d := &doc{Title: "bad \xff byte", Path: "dir/\xfe.jpg", Meta: map[string]string{"path": " x\xff", "note": " y\xff "}}
+	sanitizeValue(reflect.ValueOf(d), false)
+	want := &doc{Title: "bad \uFFFD byte", Path: "dir/\uFFFD.jpg", Meta: map[string]string{"path": " x\uFFFD", "note": "y\uFFFD"}}
+	if !reflect.DeepEqual(d, want) {
+		t.Errorf("got %+q, want %+q", d, want)
+	}
+}
@@ -0,0 +1,76 @@
+package juicewrld
+
+import "context"
+
+const defaultSearchLimit = 10
+
+type SearchSection[T any] struct {
+	Items     []T  `json:"items"`
+	Truncated bool `json:"truncated"`
+}
+
+type GlobalSearchResult struct {
+	Query  string               `json:"query"`
+	Songs  SearchSection[Song]  `json:"songs"`
+	Albums SearchSection[Album] `json:"albums"`
+	Eras   SearchSection[Era]   `json:"eras"`
+}
+
+func (c *Client) SearchAll(ctx context.Context, query string, limit int) (GlobalSearchResult, error) {
+	query = c.normalizeSearch(query)
+	if query == "" {
+		return GlobalSearchResult{}, &ValidationError{APIError{Message: "search query must not be empty"}}
+	}
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	needle := foldString(query)
+	res := GlobalSearchResult{Query: query}
+
+	err := runConcurrent(withDefaultRetryBudget(ctx), 3, 3, func(ctx context.Context, i int) error {
+		switch i {
+		case 0:
+			songs, err := c.SearchSongs(ctx, query, nil, nil, nil, limit, 0)
+			if err != nil {
+				return err
+			}
+			res.Songs.Items = songs.Songs
+			if len(res.Songs.Items) > limit {
+				res.Songs.Items = res.Songs.Items[:limit]
+			}
+			res.Songs.Truncated = songs.Total > len(res.Songs.Items)
+		case 1:
+			albums, err := c.cachedAlbums(ctx)
+			if err != nil {
+				return err
+			}
+			res.Albums = matchFolded(albums, needle, limit, func(a Album) string { return a.Title })
+		case 2:
+			eras, err := c.cachedEras(ctx)
+			if err != nil {
+				return err
+			}
+			res.Eras = matchFolded(eras, needle, limit, func(e Era) string { return e.Name })
+		}
+		return nil
+	})
+	if err != nil {
+		return GlobalSearchResult{}, err
+	}
+	return res, nil
+}
+
+func matchFolded[T any](items []T, needle string, limit int, key func(T) string) SearchSection[T] {
+	var out SearchSection[T]
+	for _, it := range items {
+		if !containsFolded(key(it), needle) {
+			continue
+		}
+		if len(out.Items) == limit {
+			out.Truncated = true
+			break
+		}
+		out.Items = append(out.Items, it)
+	}
+	return out
+}
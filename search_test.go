@@ -0,0 +1,43 @@
+package juicewrld
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestSearchSongsQueryString(t *testing.T) {
+	tests := []struct {
+		name            string
+		limit, offset   int
+		wantQuery       string
+		effectiveOffset int
+	}{
+		{"no limit", 0, 0, "search=lucid", 0},
+		{"limit and offset", 25, 30, "page=2&page_size=25&search=lucid", 25},
+		{"offset on a page boundary", 25, 50, "page=3&page_size=25&search=lucid", 50},
+		{"offset without limit", 0, 30, "search=lucid", 0},
+		{"negative limit", -5, 10, "search=lucid", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			api := newFakeAPI(t, map[string]http.HandlerFunc{
+				"/juicewrld/songs/": func(w http.ResponseWriter, r *http.Request) {
+					got = r.URL.RawQuery
+					writeJSON(w, http.StatusOK, map[string]interface{}{"count": 0, "results": []Song{}})
+				},
+			})
+			res, err := api.client().SearchSongs(context.Background(), "lucid", nil, nil, nil, tt.limit, tt.offset)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.wantQuery {
+				t.Errorf("query = %q, want %q", got, tt.wantQuery)
+			}
+			if res.EffectiveOffset != tt.effectiveOffset {
+				t.Errorf("EffectiveOffset = %d, want %d", res.EffectiveOffset, tt.effectiveOffset)
+			}
+		})
+	}
+}
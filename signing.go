@@ -0,0 +1,147 @@
+package juicewrld
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// RequestSigner adds authentication to an outgoing request. bodySHA256 is
+// the SHA-256 of the request body, or nil when the request has no body.
+type RequestSigner func(req *http.Request, bodySHA256 []byte) error
+
+// WithRequestSigner signs every request the client sends, including each
+// retry attempt and every redirect hop. The Date header is set to the
+// current time before the signer runs. The body is hashed once per request;
+// a body that cannot be replayed, such as an UploadFile stream, is read into
+// memory first so that its hash matches what is sent.
+func WithRequestSigner(sign RequestSigner) Option {
+	return func(c *Client) {
+		c.signer = sign
+	}
+}
+
+// HMACSigner returns a reference signer that sets
+//
+//	Authorization: HMAC-SHA256 keyId="<keyID>", signature="<base64>"
+//
+// where the signature is HMAC-SHA256 with secret over the method, the
+// request URI, the Date header and the hex body hash, joined by newlines.
+func HMACSigner(keyID string, secret []byte) RequestSigner {
+	return func(req *http.Request, bodySHA256 []byte) error {
+		mac := hmac.New(sha256.New, secret)
+		fmt.Fprintf(mac, "%s\n%s\n%s\n%s", req.Method, req.URL.RequestURI(), req.Header.Get("Date"), hex.EncodeToString(bodySHA256))
+		sig := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+		req.Header.Set("Authorization", fmt.Sprintf(`HMAC-SHA256 keyId=%q, signature=%q`, keyID, sig))
+		return nil
+	}
+}
+
+func (c *Client) installSigner() {
+	if c.signer == nil {
+		return
+	}
+	hc := *c.HTTPClient
+	hc.Transport = &signingTransport{client: c, base: hc.Transport}
+	c.HTTPClient = &hc
+}
+
+// signingTransport signs at the transport level so redirects, which
+// http.Client issues as fresh requests, are signed too.
+type signingTransport struct {
+	client *Client
+	base   http.RoundTripper
+}
+
+func (t *signingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	sum, err := bodyHash(req)
+	if err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, fmt.Errorf("sign request: %w", err)
+	}
+	signed := req.Clone(req.Context())
+	signed.Header.Set("Date", t.client.now().UTC().Format(http.TimeFormat))
+	if err := t.client.signer(signed, sum); err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, fmt.Errorf("sign request: %w", err)
+	}
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return base.RoundTrip(signed)
+}
+
+func (t *signingTransport) CloseIdleConnections() {
+	if ci, ok := t.base.(interface{ CloseIdleConnections() }); ok {
+		ci.CloseIdleConnections()
+	}
+}
+
+// bodyHashKey carries a request's body hash, computed once by hashBody, to
+// every round trip made for it.
+type bodyHashKey struct{}
+
+var errUnsignableBody = errors.New("juicewrld: cannot sign a request body that cannot be replayed")
+
+// hashBody makes req's body replayable, buffering it if needed, and records
+// its hash in the request context.
+func hashBody(req *http.Request) (*http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+	if req.GetBody == nil {
+		data, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.ContentLength = int64(len(data))
+		req.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(data)), nil }
+		req.Body, _ = req.GetBody()
+	}
+	sum, err := sumBody(req)
+	if err != nil {
+		return nil, err
+	}
+	return req.WithContext(context.WithValue(req.Context(), bodyHashKey{}, sum)), nil
+}
+
+// bodyHash returns the hash recorded by hashBody. Requests built elsewhere
+// are hashed here, if their body can be replayed.
+func bodyHash(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if sum, ok := req.Context().Value(bodyHashKey{}).([]byte); ok {
+		return sum, nil
+	}
+	if req.GetBody == nil {
+		return nil, errUnsignableBody
+	}
+	return sumBody(req)
+}
+
+func sumBody(req *http.Request) ([]byte, error) {
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, body); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
@@ -0,0 +1,112 @@
+package juicewrld
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// verifyHMAC checks the HMACSigner signature against the request the
+// server actually received, answering 401 on a mismatch.
+func verifyHMAC(keyID string, secret []byte, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			statusHandler(http.StatusBadRequest)(w, r)
+			return
+		}
+		var sum string
+		if len(body) > 0 {
+			h := sha256.Sum256(body)
+			sum = hex.EncodeToString(h[:])
+		}
+		mac := hmac.New(sha256.New, secret)
+		fmt.Fprintf(mac, "%s\n%s\n%s\n%s", r.Method, r.URL.RequestURI(), r.Header.Get("Date"), sum)
+		want := fmt.Sprintf(`HMAC-SHA256 keyId=%q, signature=%q`, keyID, base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+		if r.Header.Get("Date") == "" || r.Header.Get("Authorization") != want {
+			statusHandler(http.StatusUnauthorized)(w, r)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// onlyReader hides every method but Read, so the request body cannot be
+// replayed by net/http.
+type onlyReader struct{ io.Reader }
+
+func TestHMACSignerVerifiesOnServer(t *testing.T) {
+	secret := []byte("s3cret")
+	sign := func(h http.HandlerFunc) http.HandlerFunc { return verifyHMAC("key-1", secret, h) }
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"/juicewrld/songs/7/":          sign(jsonHandler(map[string]interface{}{"id": 7, "name": "Song"})),
+		"/juicewrld/start-zip-job/":    sign(jsonHandler(map[string]interface{}{"job_id": "j1"})),
+		"/juicewrld/upload/":           sign(jsonHandler(map[string]interface{}{"id": 1})),
+		"/juicewrld/moved-zip-job/":    sign(jsonHandler(map[string]interface{}{"job_id": "j2"})),
+		"/juicewrld/redirect-zip-job/": sign(http.RedirectHandler("/juicewrld/moved-zip-job/", http.StatusTemporaryRedirect).ServeHTTP),
+	})
+	ctx := context.Background()
+	c := api.client(WithRequestSigner(HMACSigner("key-1", secret)))
+
+	if _, err := c.GetSong(ctx, 7); err != nil {
+		t.Errorf("GET: %v", err)
+	}
+	if id, err := c.StartZipJob(ctx, []string{"a.mp3"}); err != nil || id != "j1" {
+		t.Errorf("POST: id %q, err %v", id, err)
+	}
+	var out map[string]string
+	if err := c.post(ctx, "/juicewrld/redirect-zip-job/", map[string]string{"path": "a.mp3"}, &out); err != nil || out["job_id"] != "j2" {
+		t.Errorf("redirected POST: %v, err %v", out, err)
+	}
+	content := strings.NewReader("streamed upload body")
+	if _, err := c.UploadFile(ctx, "/juicewrld/upload/", map[string]string{"k": "v"}, "file", "a.txt", onlyReader{content}, int64(content.Len())); err != nil {
+		t.Errorf("streamed upload: %v", err)
+	}
+
+	wrong := api.client(WithRequestSigner(HMACSigner("key-1", []byte("other"))))
+	if _, err := wrong.GetSong(ctx, 7); err == nil {
+		t.Error("request signed with the wrong secret was accepted")
+	}
+}
+
+func TestSigningHashesBodyOnce(t *testing.T) {
+	var hashed atomic.Int32
+	body := func() (io.ReadCloser, error) {
+		hashed.Add(1)
+		return io.NopCloser(strings.NewReader("{}")), nil
+	}
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"/a/": http.RedirectHandler("/b/", http.StatusTemporaryRedirect).ServeHTTP,
+		"/b/": jsonHandler(map[string]string{}),
+	})
+	var sums []string
+	c := api.client(WithRequestSigner(func(req *http.Request, sum []byte) error {
+		sums = append(sums, hex.EncodeToString(sum))
+		return nil
+	}))
+
+	req, err := c.newRequest(context.Background(), http.MethodPost, api.URL+"/a/", strings.NewReader("{}"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.GetBody = body
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if len(sums) != 2 || sums[0] != sums[1] || sums[0] == "" {
+		t.Errorf("signed body hashes %q, want the same hash on both hops", sums)
+	}
+	if n := hashed.Load(); n != 1 {
+		t.Errorf("body replayed %d times after hashing, want 1 (the redirect)", n)
+	}
+}
@@ -0,0 +1,123 @@
+package juicewrld
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// SongCache holds song records by ID for a fixed time. Concurrent
+// GetOrFetch calls for the same missing ID share one fetch. It is safe for
+// concurrent use and works without a Client, so it can be filled by hand
+// in tests.
+type SongCache struct {
+	ttl   time.Duration
+	clock Clock
+
+	mu       sync.Mutex
+	entries  map[int]cachedSong
+	inflight map[int]*songFetch
+}
+
+type cachedSong struct {
+	song      Song
+	fetchedAt time.Time
+}
+
+type songFetch struct {
+	done chan struct{}
+	song Song
+	err  error
+}
+
+// NewSongCache returns a cache whose entries expire after ttl; a ttl of 0
+// uses the default of 10 minutes.
+func NewSongCache(ttl time.Duration) *SongCache {
+	if ttl <= 0 {
+		ttl = defaultLookupTTL
+	}
+	return &SongCache{ttl: ttl, clock: realClock{}, entries: map[int]cachedSong{}, inflight: map[int]*songFetch{}}
+}
+
+// Get returns the cached song if it has not expired.
+func (sc *SongCache) Get(songID int) (Song, bool) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return sc.getLocked(songID)
+}
+
+func (sc *SongCache) getLocked(songID int) (Song, bool) {
+	e, ok := sc.entries[songID]
+	if !ok || sc.clock.Now().Sub(e.fetchedAt) >= sc.ttl {
+		return Song{}, false
+	}
+	return e.song, true
+}
+
+// Put stores song under its ID.
+func (sc *SongCache) Put(song Song) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.entries[song.ID] = cachedSong{song: song, fetchedAt: sc.clock.Now()}
+}
+
+// Delete drops the entry for songID.
+func (sc *SongCache) Delete(songID int) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	delete(sc.entries, songID)
+}
+
+// Len returns the number of entries, expired ones included. A nil cache
+// has none.
+func (sc *SongCache) Len() int {
+	if sc == nil {
+		return 0
+	}
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return len(sc.entries)
+}
+
+// GetOrFetch returns the cached song or calls fetch and caches its result.
+// Callers arriving while a fetch for the same ID is running wait for it
+// instead of fetching again. Errors are not cached.
+func (sc *SongCache) GetOrFetch(ctx context.Context, songID int, fetch func(context.Context, int) (Song, error)) (Song, error) {
+	sc.mu.Lock()
+	if s, ok := sc.getLocked(songID); ok {
+		sc.mu.Unlock()
+		return s, nil
+	}
+	if f, ok := sc.inflight[songID]; ok {
+		sc.mu.Unlock()
+		select {
+		case <-f.done:
+			return f.song, f.err
+		case <-ctx.Done():
+			return Song{}, ctx.Err()
+		}
+	}
+	f := &songFetch{done: make(chan struct{})}
+	sc.inflight[songID] = f
+	sc.mu.Unlock()
+
+	f.song, f.err = fetch(ctx, songID)
+	sc.mu.Lock()
+	delete(sc.inflight, songID)
+	if f.err == nil {
+		sc.entries[songID] = cachedSong{song: f.song, fetchedAt: sc.clock.Now()}
+	}
+	sc.mu.Unlock()
+	close(f.done)
+	return f.song, f.err
+}
+
+// GetSongCached is GetSong through the client's song cache when WithCache
+// is set, so repeated and concurrent lookups of one ID make a single
+// request. Without WithCache it is the same as GetSong.
+func (c *Client) GetSongCached(ctx context.Context, songID int) (Song, error) {
+	if c.songCache == nil {
+		return c.GetSong(ctx, songID)
+	}
+	return c.songCache.GetOrFetch(ctx, songID, c.GetSong)
+}
@@ -0,0 +1,127 @@
+package juicewrld
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// manualClock is a Clock that only moves when advanced.
+type manualClock struct {
+	realClock
+	mu sync.Mutex
+	at time.Time
+}
+
+func (c *manualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.at
+}
+
+func (c *manualClock) advance(d time.Duration) {
+	c.mu.Lock()
+	c.at = c.at.Add(d)
+	c.mu.Unlock()
+}
+
+func TestGetSongCachedSharesConcurrentFetch(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	var once sync.Once
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"/juicewrld/songs/1/": func(w http.ResponseWriter, r *http.Request) {
+			once.Do(func() { close(started) })
+			<-release
+			writeJSON(w, http.StatusOK, map[string]interface{}{"id": 1, "name": "Lucid Dreams"})
+		},
+	})
+	c := api.client(WithCache(time.Minute))
+
+	const callers = 20
+	var wg sync.WaitGroup
+	errs := make([]error, callers)
+	names := make([]string, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			s, err := c.GetSongCached(context.Background(), 1)
+			names[i], errs[i] = s.Name, err
+		}(i)
+	}
+	<-started
+	// Give the other callers time to queue behind the running fetch;
+	// any that arrive later hit the cache, which the count also allows.
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	for i := range errs {
+		if errs[i] != nil || names[i] != "Lucid Dreams" {
+			t.Errorf("caller %d got %q, %v", i, names[i], errs[i])
+		}
+	}
+	if n := api.hitCount("/juicewrld/songs/1/"); n != 1 {
+		t.Errorf("%d upstream requests for %d concurrent callers, want 1", n, callers)
+	}
+}
+
+func TestSongCacheExpiry(t *testing.T) {
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"/juicewrld/songs/1/": jsonHandler(map[string]interface{}{"id": 1, "name": "Lucid Dreams"}),
+	})
+	clock := &manualClock{at: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	c := api.client(WithCache(time.Minute), WithClock(clock))
+	ctx := context.Background()
+
+	for _, step := range []struct {
+		advance time.Duration
+		hits    int
+	}{
+		{0, 1},
+		{59 * time.Second, 1},
+		{time.Second, 2},
+		{30 * time.Second, 2},
+	} {
+		clock.advance(step.advance)
+		if _, err := c.GetSongCached(ctx, 1); err != nil {
+			t.Fatal(err)
+		}
+		if n := api.hitCount("/juicewrld/songs/1/"); n != step.hits {
+			t.Errorf("after %v more: %d requests, want %d", step.advance, n, step.hits)
+		}
+	}
+	if _, ok := c.songCache.Get(1); !ok {
+		t.Error("refetched song not cached")
+	}
+	clock.advance(time.Minute)
+	if _, ok := c.songCache.Get(1); ok {
+		t.Error("Get returned an expired song")
+	}
+}
+
+func TestSongCacheDoesNotCacheErrors(t *testing.T) {
+	var fail atomic.Bool
+	fail.Store(true)
+	api := newFakeAPI(t, map[string]http.HandlerFunc{
+		"/juicewrld/songs/1/": func(w http.ResponseWriter, r *http.Request) {
+			if fail.Load() {
+				statusHandler(http.StatusNotFound)(w, r)
+				return
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{"id": 1, "name": "Lucid Dreams"})
+		},
+	})
+	c := api.client(WithCache(time.Minute))
+	ctx := context.Background()
+	if _, err := c.GetSongCached(ctx, 1); err == nil {
+		t.Fatal("first lookup succeeded against a 404")
+	}
+	fail.Store(false)
+	if s, err := c.GetSongCached(ctx, 1); err != nil || s.Name != "Lucid Dreams" {
+		t.Errorf("second lookup = %+v, %v; want a fresh fetch", s, err)
+	}
+}
@@ -0,0 +1,286 @@
+package juicewrld
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Songs []Song
+
+func (s Song) ParsedDuration() (time.Duration, error) {
+	length := strings.TrimSpace(s.Length)
+	if length == "" {
+		return 0, fmt.Errorf("song %d has no length", s.ID)
+	}
+	if d, err := time.ParseDuration(length); err == nil {
+		return d, nil
+	}
+	parts := strings.Split(length, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("song %d has unparseable length %q", s.ID, s.Length)
+	}
+	var total time.Duration
+	for _, p := range parts {
+		n, err := strconv.Atoi(strings.TrimSpace(p))
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("song %d has unparseable length %q", s.ID, s.Length)
+		}
+		total = total*60 + time.Duration(n)
+	}
+	return total * time.Second, nil
+}
+
+func sumDurations(songs Songs) (time.Duration, error) {
+	var total time.Duration
+	for _, s := range songs {
+		d, err := s.ParsedDuration()
+		if err != nil {
+			return total, err
+		}
+		total += d
+	}
+	return total, nil
+}
+
+func (songs Songs) FilterByMinLength(min time.Duration) Songs {
+	return songs.filterByLength(func(d time.Duration) bool { return d >= min })
+}
+
+func (songs Songs) FilterByMaxLength(max time.Duration) Songs {
+	return songs.filterByLength(func(d time.Duration) bool { return d <= max })
+}
+
+// FilterByLengthRange keeps songs whose length lies in [min, max]. Like the
+// other length filters it drops songs whose length cannot be parsed.
+func (songs Songs) FilterByLengthRange(min, max time.Duration) Songs {
+	return songs.filterByLength(func(d time.Duration) bool { return d >= min && d <= max })
+}
+
+// FilterByYear keeps songs released in year, dropping songs whose release
+// date cannot be parsed.
+func (songs Songs) FilterByYear(year int) Songs {
+	return songs.FilterByYearRange(year, year)
+}
+
+// FilterByYearRange keeps songs released between start and end inclusive.
+func (songs Songs) FilterByYearRange(start, end int) Songs {
+	var out Songs
+	for _, s := range songs {
+		if t, err := s.ReleaseDateParsed(); err == nil && t.Year() >= start && t.Year() <= end {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func (songs Songs) filterByLength(keep func(time.Duration) bool) Songs {
+	var out Songs
+	for _, s := range songs {
+		if d, err := s.ParsedDuration(); err == nil && keep(d) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func (songs Songs) TotalDuration() (time.Duration, error) {
+	return sumDurations(songs)
+}
+
+func (songs Songs) AverageDuration() (time.Duration, error) {
+	if len(songs) == 0 {
+		return 0, fmt.Errorf("no songs to average")
+	}
+	total, err := sumDurations(songs)
+	if err != nil {
+		return 0, err
+	}
+	return total / time.Duration(len(songs)), nil
+}
+
+// collectSongs returns every song matching q, without removed songs.
+func (c *Client) collectSongs(ctx context.Context, q url.Values) (Songs, error) {
+	songs, err := NewPaginator[Song](c, "/juicewrld/songs/", q).All(ctx)
+	return c.exclude(nil, songs), err
+}
+
+func (songs Songs) EagerLoadEras(ctx context.Context, c *Client) (Songs, error) {
+	var ids []int
+	seen := map[int]bool{}
+	for _, s := range songs {
+		if s.Era.ID == 0 || seen[s.Era.ID] {
+			continue
+		}
+		seen[s.Era.ID] = true
+		ids = append(ids, s.Era.ID)
+	}
+
+	eras := make([]Era, len(ids))
+	err := runConcurrent(withDefaultRetryBudget(ctx), len(ids), defaultConcurrency, func(ctx context.Context, i int) error {
+		era, err := c.GetEra(ctx, ids[i])
+		if err != nil {
+			return err
+		}
+		eras[i] = era
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int]Era, len(eras))
+	for i, e := range eras {
+		byID[ids[i]] = e
+	}
+	out := make(Songs, len(songs))
+	for i, s := range songs {
+		if e, ok := byID[s.Era.ID]; ok {
+			s.Era = e
+		}
+		out[i] = s
+	}
+	return out, nil
+}
+
+func (s Song) WithFullDetails(ctx context.Context, c *Client) (Song, error) {
+	full, err := c.GetSong(ctx, s.ID)
+	if err != nil {
+		return s, err
+	}
+	return mergeSong(s, full), nil
+}
+
+func mergeSong(base, full Song) Song {
+	out := base
+	dst := reflect.ValueOf(&out).Elem()
+	src := reflect.ValueOf(full)
+	for i := 0; i < src.NumField(); i++ {
+		if f := src.Field(i); !f.IsZero() {
+			dst.Field(i).Set(f)
+		}
+	}
+	return out
+}
+
+func (songs Songs) sorted(key SortKey, order Order) Songs {
+	out := make(Songs, len(songs))
+	copy(out, songs)
+	SortSongs(out, key, order)
+	return out
+}
+
+func (songs Songs) SortByReleaseDate() Songs {
+	return songs.sorted(SortKeyReleaseDate, Ascending)
+}
+
+func (songs Songs) SortByReleaseDateDesc() Songs {
+	return songs.sorted(SortKeyReleaseDate, Descending)
+}
+
+func (songs Songs) MostRecent(n int) Songs {
+	return songs.SortByReleaseDateDesc().datedPrefix(n)
+}
+
+func (songs Songs) Earliest(n int) Songs {
+	return songs.SortByReleaseDate().datedPrefix(n)
+}
+
+func (songs Songs) Newest() (Song, bool) {
+	if s := songs.MostRecent(1); len(s) == 1 {
+		return s[0], true
+	}
+	return Song{}, false
+}
+
+func (songs Songs) Oldest() (Song, bool) {
+	if s := songs.Earliest(1); len(s) == 1 {
+		return s[0], true
+	}
+	return Song{}, false
+}
+
+func (songs Songs) SortByLength() Songs {
+	return songs.sorted(SortKeyLength, Ascending)
+}
+
+func (songs Songs) SortByLengthDesc() Songs {
+	return songs.sorted(SortKeyLength, Descending)
+}
+
+func (songs Songs) LongestSong() (Song, bool) {
+	return songs.SortByLengthDesc().firstTimed()
+}
+
+func (songs Songs) ShortestSong() (Song, bool) {
+	return songs.SortByLength().firstTimed()
+}
+
+func (songs Songs) firstTimed() (Song, bool) {
+	if len(songs) == 0 {
+		return Song{}, false
+	}
+	if _, err := songs[0].ParsedDuration(); err != nil {
+		return Song{}, false
+	}
+	return songs[0], true
+}
+
+func (songs Songs) datedPrefix(n int) Songs {
+	var out Songs
+	for _, s := range songs {
+		if len(out) >= n {
+			break
+		}
+		if _, err := s.ReleaseDateParsed(); err != nil {
+			break
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+func (songs Songs) GroupByCategory() map[string]Songs {
+	out := make(map[string]Songs)
+	for _, s := range songs {
+		out[s.Category] = append(out[s.Category], s)
+	}
+	return out
+}
+
+func (songs Songs) ByCategory() map[string]Songs {
+	return songs.GroupByCategory()
+}
+
+func (songs Songs) Categories() []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, s := range songs {
+		if s.Category == "" || seen[s.Category] {
+			continue
+		}
+		seen[s.Category] = true
+		out = append(out, s.Category)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func (songs Songs) Eras() []Era {
+	seen := map[int]bool{}
+	var out []Era
+	for _, s := range songs {
+		if s.Era.ID == 0 || seen[s.Era.ID] {
+			continue
+		}
+		seen[s.Era.ID] = true
+		out = append(out, s.Era)
+	}
+	sortErasChronologically(out)
+	return out
+}
@@ -0,0 +1,122 @@
+package juicewrld
+
+import (
+	"sort"
+	"time"
+)
+
+type SortKey int
+
+const (
+	SortKeyName SortKey = iota
+	SortKeyReleaseDate
+	SortKeyEra
+	SortKeyLength
+)
+
+type Order int
+
+const (
+	Ascending Order = iota
+	Descending
+)
+
+type sortEntry struct {
+	index   int
+	missing bool
+	text    string
+	raw     string
+	when    time.Time
+	dur     time.Duration
+}
+
+func compareEntries(a, b sortEntry, key SortKey, order Order) bool {
+	if a.missing || b.missing {
+		return !a.missing && b.missing
+	}
+	var cmp int
+	switch key {
+	case SortKeyReleaseDate:
+		cmp = a.when.Compare(b.when)
+	case SortKeyLength:
+		switch {
+		case a.dur < b.dur:
+			cmp = -1
+		case a.dur > b.dur:
+			cmp = 1
+		}
+	default:
+		cmp = compareStrings(a.text, b.text)
+		if cmp == 0 {
+			cmp = compareStrings(a.raw, b.raw)
+		}
+	}
+	if order == Descending {
+		return cmp > 0
+	}
+	return cmp < 0
+}
+
+func compareStrings(a, b string) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// SortSongs sorts songs in place using a case- and accent-insensitive
+// collation for text keys. The sort is stable, and songs whose key is empty
+// or unparseable are kept at the end in their original order regardless of
+// order.
+func SortSongs(songs []Song, key SortKey, order Order) {
+	entries := make([]sortEntry, len(songs))
+	for i, s := range songs {
+		e := sortEntry{index: i}
+		switch key {
+		case SortKeyReleaseDate:
+			t, err := s.ReleaseDateParsed()
+			e.when, e.missing = t, err != nil
+		case SortKeyLength:
+			d, err := s.ParsedDuration()
+			e.dur, e.missing = d, err != nil
+		case SortKeyEra:
+			e.raw = s.Era.Name
+			e.text, e.missing = foldString(s.Era.Name), s.Era.Name == ""
+		default:
+			e.raw = s.Name
+			e.text, e.missing = foldString(s.Name), s.Name == ""
+		}
+		entries[i] = e
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return compareEntries(entries[i], entries[j], key, order) })
+	sorted := make([]Song, len(songs))
+	for i, e := range entries {
+		sorted[i] = songs[e.index]
+	}
+	copy(songs, sorted)
+}
+
+// SortAlbums sorts albums in place like SortSongs. Albums only support
+// SortKeyName (by title) and SortKeyReleaseDate; other keys sort by title.
+func SortAlbums(albums []Album, key SortKey, order Order) {
+	entries := make([]sortEntry, len(albums))
+	for i, a := range albums {
+		e := sortEntry{index: i}
+		if key == SortKeyReleaseDate {
+			e.when, e.missing = a.ReleaseDate.Time, a.ReleaseDate.IsZero()
+		} else {
+			e.raw = a.Title
+			e.text, e.missing = foldString(a.Title), a.Title == ""
+		}
+		entries[i] = e
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return compareEntries(entries[i], entries[j], key, order) })
+	sorted := make([]Album, len(albums))
+	for i, e := range entries {
+		sorted[i] = albums[e.index]
+	}
+	copy(albums, sorted)
+}
@@ -0,0 +1,88 @@
+package juicewrld
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestSortSongsCollation(t *testing.T) {
+	songs := []Song{
+		{ID: 1, Name: "zeta"},
+		{ID: 2, Name: ""},
+		{ID: 3, Name: "Émpty"},
+		{ID: 4, Name: "alpha"},
+		{ID: 5, Name: "Empty"},
+		{ID: 6, Name: ""},
+	}
+	SortSongs(songs, SortKeyName, Ascending)
+	if got := ids(songs); !reflect.DeepEqual(got, []int{4, 5, 3, 1, 2, 6}) {
+		t.Errorf("ascending = %v", got)
+	}
+	SortSongs(songs, SortKeyName, Descending)
+	if got := ids(songs); !reflect.DeepEqual(got, []int{1, 3, 5, 4, 2, 6}) {
+		t.Errorf("descending = %v, want unnamed songs still last", got)
+	}
+
+	dated := []Song{
+		{ID: 1, ReleaseDate: "2019"},
+		{ID: 2, ReleaseDate: "soon"},
+		{ID: 3, ReleaseDate: "2018-05-23"},
+		{ID: 4, Length: "3:59"},
+	}
+	SortSongs(dated, SortKeyReleaseDate, Ascending)
+	if got := ids(dated); !reflect.DeepEqual(got, []int{3, 1, 2, 4}) {
+		t.Errorf("by release date = %v", got)
+	}
+}
+
+// benchmarkSongs returns n songs with varied, partly accented names,
+// lengths and release dates, a tenth of them missing each.
+func benchmarkSongs(n int) []Song {
+	r := rand.New(rand.NewSource(1))
+	words := []string{"Lucid", "dreams", "Émpty", "robbery", "Wishing", "Well", "Ça", "bandit", "Legends"}
+	songs := make([]Song, n)
+	for i := range songs {
+		s := Song{ID: i}
+		if r.Intn(10) > 0 {
+			s.Name = words[r.Intn(len(words))] + " " + words[r.Intn(len(words))]
+			s.Era.Name = words[r.Intn(len(words))]
+			s.Length = fmt.Sprintf("%d:%02d", r.Intn(6), r.Intn(60))
+			s.ReleaseDate = fmt.Sprintf("%d-%02d-%02d", 2015+r.Intn(8), 1+r.Intn(12), 1+r.Intn(28))
+		}
+		songs[i] = s
+	}
+	return songs
+}
+
+func BenchmarkSortSongs(b *testing.B) {
+	base := benchmarkSongs(5000)
+	for _, key := range []struct {
+		name string
+		key  SortKey
+	}{{"Name", SortKeyName}, {"ReleaseDate", SortKeyReleaseDate}, {"Era", SortKeyEra}, {"Length", SortKeyLength}} {
+		b.Run(key.name, func(b *testing.B) {
+			songs := make([]Song, len(base))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				copy(songs, base)
+				SortSongs(songs, key.key, Ascending)
+			}
+		})
+	}
+}
+
+func BenchmarkSortAlbums(b *testing.B) {
+	songs := benchmarkSongs(5000)
+	base := make([]Album, len(songs))
+	for i, s := range songs {
+		base[i] = Album{ID: i, Title: s.Name}
+	}
+	albums := make([]Album, len(base))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		copy(albums, base)
+		SortAlbums(albums, SortKeyName, Ascending)
+	}
+}
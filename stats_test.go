@@ -0,0 +1,117 @@
+package juicewrld
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+const statsBody = `{"total_songs":3,"category_stats":{"released":2,"unreleased":1},"era_stats":{"DRFL":2,"GBGR":1}}`
+
+// cannedTransport answers every request with body, without a network, so
+// allocation counts cover only the client.
+type cannedTransport struct {
+	body []byte
+}
+
+func (t cannedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode:    http.StatusOK,
+		Header:        http.Header{"Content-Type": {"application/json"}, "Content-Length": {strconv.Itoa(len(t.body))}},
+		Body:          io.NopCloser(bytes.NewReader(t.body)),
+		ContentLength: int64(len(t.body)),
+		Request:       req,
+	}, nil
+}
+
+func newCannedClient(body string) *Client {
+	return New("http://example.invalid", WithHTTPClient(&http.Client{Transport: cannedTransport{body: []byte(body)}}))
+}
+
+// statsIntoAllocBudget bounds what GetStatsInto may allocate per call once
+// the destination maps have capacity: the request itself, mostly.
+const statsIntoAllocBudget = 35
+
+func TestGetStatsIntoMatchesGetStats(t *testing.T) {
+	ctx := context.Background()
+	c := newCannedClient(statsBody)
+	want, err := c.GetStats(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst := Stats{TotalSongs: 9, CategoryStats: map[string]int{"stale": 1}, EraStats: map[string]int{}}
+	if err := c.GetStatsInto(ctx, &dst); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dst, want) {
+		t.Errorf("GetStatsInto = %+v, want %+v", dst, want)
+	}
+
+	into := testing.AllocsPerRun(100, func() {
+		if err := c.GetStatsInto(ctx, &dst); err != nil {
+			t.Fatal(err)
+		}
+	})
+	fresh := testing.AllocsPerRun(100, func() {
+		if _, err := c.GetStats(ctx); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if into > statsIntoAllocBudget {
+		t.Errorf("GetStatsInto allocates %.0f times per call, budget %d", into, statsIntoAllocBudget)
+	}
+	if into >= fresh {
+		t.Errorf("GetStatsInto allocates %.0f times per call, GetStats %.0f; want fewer", into, fresh)
+	}
+}
+
+func BenchmarkGetStats(b *testing.B) {
+	ctx := context.Background()
+	c := newCannedClient(statsBody)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := c.GetStats(ctx); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetStatsInto(b *testing.B) {
+	ctx := context.Background()
+	c := newCannedClient(statsBody)
+	var dst Stats
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := c.GetStatsInto(ctx, &dst); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkDecodeLargeResponse decodes a song page too large to pool; it
+// is streamed into the decoder rather than buffered whole first.
+func BenchmarkDecodeLargeResponse(b *testing.B) {
+	var page bytes.Buffer
+	page.WriteString(`{"count":5000,"results":[`)
+	for i := 0; i < 5000; i++ {
+		if i > 0 {
+			page.WriteByte(',')
+		}
+		page.WriteString(`{"id":` + strconv.Itoa(i) + `,"name":"Song","credited_artists":"Juice WRLD","notes":"` + string(bytes.Repeat([]byte("x"), 200)) + `"}`)
+	}
+	page.WriteString(`]}`)
+	ctx := context.Background()
+	c := newCannedClient(page.String())
+	b.SetBytes(int64(page.Len()))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var out PaginatedSongsResponse
+		if err := c.get(ctx, "/juicewrld/songs/", nil, &out); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
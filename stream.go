@@ -0,0 +1,46 @@
+package juicewrld
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// OpenStream starts a GET of filePath and returns the raw response so its
+// body can be streamed without buffering. rangeHeader, if set, is forwarded
+// as the Range header. Error statuses are returned as errors with the body
+// already closed; otherwise the caller must close resp.Body. params are
+// merged into the query as for DownloadURL.
+func (c *Client) OpenStream(ctx context.Context, filePath, rangeHeader string, params ...url.Values) (*http.Response, error) {
+	return c.openStream(ctx, http.MethodGet, filePath, rangeHeader, params)
+}
+
+// HeadStream is OpenStream with a HEAD request: it returns the headers a
+// GET would, such as Content-Length and Content-Range, without the file.
+func (c *Client) HeadStream(ctx context.Context, filePath, rangeHeader string, params ...url.Values) (*http.Response, error) {
+	return c.openStream(ctx, http.MethodHead, filePath, rangeHeader, params)
+}
+
+func (c *Client) openStream(ctx context.Context, method, filePath, rangeHeader string, params []url.Values) (*http.Response, error) {
+	req, err := c.newRequest(ctx, method, c.downloadURL(filePath, params...), nil, "")
+	if err != nil {
+		return nil, err
+	}
+	if rangeHeader != "" {
+		req.Header.Set("Range", rangeHeader)
+	}
+	resp, err := c.sendRetrying(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResponse(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if err := c.checkDownloadType(resp, filePath); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	resp.Body = c.throttle(ctx, resp.Body)
+	return resp, nil
+}
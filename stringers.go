@@ -0,0 +1,63 @@
+package juicewrld
+
+import "fmt"
+
+// The String methods use pointer receivers and print a nil model as
+// "<nil>", as fmt does. fmt only applies them to pointers, so log &album
+// rather than album.
+
+func (a *Artist) String() string {
+	if a == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("Artist{ID: %d, Name: %q}", a.ID, a.Name)
+}
+
+// String omits Year when the release date is unknown.
+func (a *Album) String() string {
+	if a == nil {
+		return "<nil>"
+	}
+	if a.ReleaseDate.IsZero() {
+		return fmt.Sprintf("Album{ID: %d, Title: %q, Artist: %q}", a.ID, a.Title, a.Artist.Name)
+	}
+	return fmt.Sprintf("Album{ID: %d, Title: %q, Artist: %q, Year: %d}", a.ID, a.Title, a.Artist.Name, a.ReleaseDate.Year())
+}
+
+func (e *Era) String() string {
+	if e == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("Era{ID: %d, Name: %q, TimeFrame: %q}", e.ID, e.Name, e.TimeFrame)
+}
+
+func (s *Song) String() string {
+	if s == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("Song{ID: %d, Name: %q, Category: %q, Era: %q}", s.ID, s.Name, s.Category, s.Era.Name)
+}
+
+// The types below embed a model and would otherwise inherit its String,
+// hiding their other fields.
+
+func (a *AlbumWithSongs) String() string {
+	if a == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("AlbumWithSongs{%v, Songs: %d}", &a.Album, len(a.Songs))
+}
+
+func (e *EraWithSongs) String() string {
+	if e == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("EraWithSongs{%v, Songs: %d}", &e.Era, len(e.Songs))
+}
+
+func (s *SongWithRelated) String() string {
+	if s == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("SongWithRelated{%v, RelatedByProducer: %d, RelatedByEra: %d}", &s.Song, len(s.RelatedByProducer), len(s.RelatedByEra))
+}
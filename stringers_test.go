@@ -0,0 +1,30 @@
+package juicewrld
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestStringersAreNilSafe(t *testing.T) {
+	album := &Album{ID: 1, Title: "999", Artist: Artist{Name: "Juice WRLD"}, ReleaseDate: FlexibleTime{time.Date(2018, 5, 23, 0, 0, 0, 0, time.UTC)}}
+	var nilSong *Song
+	var nilEra *EraWithSongs
+	tests := []struct {
+		got  fmt.Stringer
+		want string
+	}{
+		{album, `Album{ID: 1, Title: "999", Artist: "Juice WRLD", Year: 2018}`},
+		{&Album{ID: 2, Title: "Untitled"}, `Album{ID: 2, Title: "Untitled", Artist: ""}`},
+		{&Artist{ID: 1, Name: "Juice WRLD"}, `Artist{ID: 1, Name: "Juice WRLD"}`},
+		{&AlbumWithSongs{Album: *album, Songs: make(Songs, 3)}, `AlbumWithSongs{Album{ID: 1, Title: "999", Artist: "Juice WRLD", Year: 2018}, Songs: 3}`},
+		{nilSong, "<nil>"},
+		{nilEra, "<nil>"},
+		{(*Album)(nil), "<nil>"},
+	}
+	for _, tt := range tests {
+		if s := tt.got.String(); s != tt.want {
+			t.Errorf("String() = %s, want %s", s, tt.want)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package juicewrld
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+)
+
+type SongChangeType string
+
+const (
+	SongCreated SongChangeType = "created"
+	SongUpdated SongChangeType = "updated"
+	SongDeleted SongChangeType = "deleted"
+)
+
+// SongChange is one entry reported by SyncSongs. For deletions Song is the
+// last version the client saw.
+type SongChange struct {
+	Type SongChangeType
+	Song Song
+}
+
+// syncSnapshot is the catalog as of the last successful snapshot-based
+// SyncSongs call on this client.
+type syncSnapshot struct {
+	mu    sync.Mutex
+	songs map[int]Song
+}
+
+// SyncState is what an incremental sync carries from one run to the next.
+// It marshals to JSON, so it can be stored between processes and handed
+// back to SyncSongsWithState.
+type SyncState struct {
+	// Since is the high-water mark of the last successful run; zero
+	// before the first one.
+	Since time.Time `json:"since"`
+	// Songs is the catalog as of the last run that compared full
+	// snapshots. It stays nil while the server honours modified_since.
+	Songs map[int]Song `json:"songs,omitempty"`
+}
+
+// SyncSongs reports songs changed since the given time to apply and returns
+// the high-water mark to pass as since on the next run. A zero since
+// reports the whole catalog as created.
+//
+// When the server honours modified_since only the changed songs are
+// fetched; such servers don't report hard deletions, and changes are
+// reported as updates unless since is zero. Otherwise SyncSongs falls back to fetching
+// the full catalog and diffing it against the snapshot kept from this
+// client's previous sync. Without a previous snapshot every song is
+// reported as updated (or created, for a zero since). Either way, songs
+// the server marks as removed (Song.IsRemoved) are reported as deleted.
+// Use SyncSongsWithState to keep the snapshot across processes.
+//
+// If apply returns an error, syncing stops and since is returned unchanged
+// together with the error.
+func (c *Client) SyncSongs(ctx context.Context, since time.Time, apply func(SongChange) error) (time.Time, error) {
+	c.syncSnapshot.mu.Lock()
+	state := SyncState{Since: since, Songs: c.syncSnapshot.songs}
+	c.syncSnapshot.mu.Unlock()
+	if err := c.SyncSongsWithState(ctx, &state, apply); err != nil {
+		return since, err
+	}
+	if state.Songs != nil {
+		c.syncSnapshot.mu.Lock()
+		c.syncSnapshot.songs = state.Songs
+		c.syncSnapshot.mu.Unlock()
+	}
+	return state.Since, nil
+}
+
+// SyncSongsWithState is SyncSongs with caller-owned state: the snapshot
+// used for the fallback diff comes from state rather than the client, so
+// a fresh process resumes where the saved state left off. On success
+// state is advanced in place; on error it is left untouched.
+//
+// The new high-water mark is taken from the server's Date header when it
+// sends one, so skew in the local clock cannot skip changes.
+func (c *Client) SyncSongsWithState(ctx context.Context, state *SyncState, apply func(SongChange) error) error {
+	ctx = withDefaultRetryBudget(ctx)
+	ctx, clock := withServerClock(ctx)
+	supported, err := c.supportsModifiedSince(ctx)
+	if err != nil {
+		return err
+	}
+
+	if supported && !state.Since.IsZero() {
+		songs, err := c.GetAllSongs(ctx, &SongFilter{ModifiedSince: state.Since, IncludeRemoved: true})
+		if err != nil {
+			return err
+		}
+		for _, s := range songs {
+			change := SongChange{Type: SongUpdated, Song: s}
+			if s.IsRemoved() {
+				change.Type = SongDeleted
+			}
+			if err := apply(change); err != nil {
+				return err
+			}
+		}
+		state.Since, state.Songs = clock.mark(c.now()), nil
+		return nil
+	}
+
+	songs, err := c.GetAllSongs(ctx, nil)
+	if err != nil {
+		return err
+	}
+	current := make(map[int]Song, len(songs))
+	for _, s := range songs {
+		current[s.ID] = s
+	}
+	for _, change := range diffSongs(state.Songs, songs, current, state.Since.IsZero()) {
+		if err := apply(change); err != nil {
+			return err
+		}
+	}
+	state.Since, state.Songs = clock.mark(c.now()), current
+	return nil
+}
+
+type serverClockKey struct{}
+
+// serverClock records the earliest Date header among the responses to a
+// sync's requests. Date has one-second resolution and is truncated, so the
+// earliest one never runs ahead of the server's view of when the sync
+// started.
+type serverClock struct {
+	mu       sync.Mutex
+	earliest time.Time
+}
+
+func withServerClock(ctx context.Context) (context.Context, *serverClock) {
+	clock := &serverClock{}
+	return context.WithValue(ctx, serverClockKey{}, clock), clock
+}
+
+// observeServerDate feeds resp's Date header to the context's serverClock,
+// if there is one.
+func observeServerDate(ctx context.Context, resp *http.Response) {
+	clock, _ := ctx.Value(serverClockKey{}).(*serverClock)
+	if clock == nil {
+		return
+	}
+	t, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return
+	}
+	clock.mu.Lock()
+	if clock.earliest.IsZero() || t.Before(clock.earliest) {
+		clock.earliest = t
+	}
+	clock.mu.Unlock()
+}
+
+// mark returns the earliest server date seen, or fallback when no response
+// carried one.
+func (s *serverClock) mark(fallback time.Time) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.earliest.IsZero() {
+		return fallback.UTC()
+	}
+	return s.earliest.UTC()
+}
+
+func diffSongs(previous map[int]Song, songs Songs, current map[int]Song, fresh bool) []SongChange {
+	var out []SongChange
+	for _, s := range songs {
+		old, ok := previous[s.ID]
+		switch {
+		case previous == nil && fresh, previous != nil && !ok:
+			out = append(out, SongChange{Type: SongCreated, Song: s})
+		case previous == nil, !reflect.DeepEqual(old, s):
+			out = append(out, SongChange{Type: SongUpdated, Song: s})
+		}
+	}
+	var deleted []int
+	for id := range previous {
+		if _, ok := current[id]; !ok {
+			deleted = append(deleted, id)
+		}
+	}
+	sort.Ints(deleted)
+	for _, id := range deleted {
+		out = append(out, SongChange{Type: SongDeleted, Song: previous[id]})
+	}
+	return out
+}
+
+// supportsModifiedSince asks for songs modified after tomorrow. A server
+// that honours the parameter returns nothing; one that ignores it returns
+// its first page. An empty answer only counts when the unfiltered catalog
+// has songs, since an empty catalog looks the same either way and is left
+// undecided. A decided answer is remembered for the client's lifetime.
+func (c *Client) supportsModifiedSince(ctx context.Context) (bool, error) {
+	if supported, ok := c.modifiedSince.known(); ok {
+		return supported, nil
+	}
+	page, err := c.ListSongs(ctx, &SongFilter{PageSize: 1, ModifiedSince: c.now().Add(24 * time.Hour), IncludeRemoved: true})
+	if err != nil {
+		return false, err
+	}
+	supported := false
+	if page.Count == 0 && len(page.Results) == 0 {
+		all, err := c.ListSongs(ctx, &SongFilter{PageSize: 1, IncludeRemoved: true})
+		if err != nil {
+			return false, err
+		}
+		if all.Count == 0 && len(all.Results) == 0 {
+			return false, nil
+		}
+		supported = true
+	}
+	c.modifiedSince.set(supported)
+	return supported, nil
+}
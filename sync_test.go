@@ -0,0 +1,150 @@
+package juicewrld
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// songCatalog serves /juicewrld/songs/ from a mutable list, optionally
+// honouring modified_since against per-song modification times.
+type songCatalog struct {
+	mu            sync.Mutex
+	songs         []Song
+	modified      map[int]time.Time
+	modifiedSince bool
+	date          time.Time
+}
+
+func (s *songCatalog) set(songs ...Song) {
+	s.mu.Lock()
+	s.songs = songs
+	s.mu.Unlock()
+}
+
+func (s *songCatalog) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	results := []Song{}
+	since, err := time.Parse(time.RFC3339, r.URL.Query().Get("modified_since"))
+	for _, song := range s.songs {
+		if s.modifiedSince && err == nil && !s.modified[song.ID].After(since) {
+			continue
+		}
+		results = append(results, song)
+	}
+	if !s.date.IsZero() {
+		w.Header().Set("Date", s.date.UTC().Format(http.TimeFormat))
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"count": len(results), "results": results})
+}
+
+// fixedClock is a Clock stopped at a given instant.
+type fixedClock struct {
+	realClock
+	at time.Time
+}
+
+func (c fixedClock) Now() time.Time { return c.at }
+
+func collectChanges(changes *[]SongChange) func(SongChange) error {
+	return func(ch SongChange) error {
+		*changes = append(*changes, ch)
+		return nil
+	}
+}
+
+func TestSyncSongsWithStateResumesAcrossClients(t *testing.T) {
+	catalog := &songCatalog{date: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)}
+	catalog.set(Song{ID: 1, Name: "Lucid Dreams"}, Song{ID: 2, Name: "Robbery"})
+	api := newFakeAPI(t, map[string]http.HandlerFunc{"/juicewrld/songs/": catalog.handle})
+
+	var state SyncState
+	var changes []SongChange
+	if err := api.client().SyncSongsWithState(context.Background(), &state, collectChanges(&changes)); err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 2 || changes[0].Type != SongCreated || changes[1].Type != SongCreated {
+		t.Fatalf("first run changes = %+v, want two creations", changes)
+	}
+	if !state.Since.Equal(catalog.date) {
+		t.Errorf("Since = %v, want the server's Date %v", state.Since, catalog.date)
+	}
+
+	saved, err := json.Marshal(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	catalog.set(Song{ID: 2, Name: "Robbery (Remix)"}, Song{ID: 3, Name: "Wishing Well"})
+	catalog.date = catalog.date.Add(time.Hour)
+
+	var restored SyncState
+	if err := json.Unmarshal(saved, &restored); err != nil {
+		t.Fatal(err)
+	}
+	changes = nil
+	if err := api.client().SyncSongsWithState(context.Background(), &restored, collectChanges(&changes)); err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		typ SongChangeType
+		id  int
+	}{{SongUpdated, 2}, {SongCreated, 3}, {SongDeleted, 1}}
+	if len(changes) != len(want) {
+		t.Fatalf("second run changes = %+v, want %v", changes, want)
+	}
+	for i, w := range want {
+		if changes[i].Type != w.typ || changes[i].Song.ID != w.id {
+			t.Errorf("change %d = %s %d, want %s %d", i, changes[i].Type, changes[i].Song.ID, w.typ, w.id)
+		}
+	}
+	if !restored.Since.Equal(catalog.date) {
+		t.Errorf("Since = %v, want %v", restored.Since, catalog.date)
+	}
+}
+
+func TestSyncSongsUsesServerDateNotLocalClock(t *testing.T) {
+	server := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	catalog := &songCatalog{
+		modifiedSince: true,
+		modified:      map[int]time.Time{1: server.Add(-time.Hour)},
+		date:          server,
+	}
+	catalog.set(Song{ID: 1, Name: "Lucid Dreams"})
+	api := newFakeAPI(t, map[string]http.HandlerFunc{"/juicewrld/songs/": catalog.handle})
+	// The local clock runs an hour ahead of the server.
+	c := api.client(WithClock(fixedClock{at: server.Add(time.Hour)}))
+
+	mark, err := c.SyncSongs(context.Background(), server.Add(-2*time.Hour), func(SongChange) error { return nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !mark.Equal(server) {
+		t.Errorf("mark = %v, want the server's Date %v", mark, server)
+	}
+}
+
+func TestSupportsModifiedSinceLeavesEmptyCatalogUndecided(t *testing.T) {
+	catalog := &songCatalog{}
+	api := newFakeAPI(t, map[string]http.HandlerFunc{"/juicewrld/songs/": catalog.handle})
+	c := api.client()
+
+	supported, err := c.supportsModifiedSince(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if supported {
+		t.Error("empty catalog reported as honouring modified_since")
+	}
+
+	catalog.set(Song{ID: 1, Name: "Lucid Dreams"})
+	if supported, err = c.supportsModifiedSince(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if supported {
+		t.Error("server that ignores modified_since reported as honouring it")
+	}
+}
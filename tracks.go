@@ -0,0 +1,68 @@
+package juicewrld
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// trackPrefix matches a leading track number such as "03 - ", "3. ",
+// "(3) ", "#3 " or "Track 3: ". A bare number followed only by a space
+// counts when zero-padded, so titles like "999" or "2 Legit" are not read
+// as track numbers.
+var trackPrefix = regexp.MustCompile(`(?i)^\s*(?:track\s*(\d{1,3})\b[.:)\-]?|\(?(\d{1,3})(?:[.:)_]|\s*-\s)|(0\d{1,2})\s|#(\d{1,3})\s)\s*\S`)
+
+// TrackNumber infers the song's position on its album from a numeric prefix
+// on its track titles, file names or name, in that order. The API has no
+// track number field, so ok is false when none of them carries one.
+func (s Song) TrackNumber() (n int, ok bool) {
+	candidates := make([]string, 0, len(s.TrackTitles)+len(s.FileNames)+1)
+	candidates = append(candidates, s.TrackTitles...)
+	candidates = append(candidates, s.FileNames...)
+	candidates = append(candidates, s.Name)
+	for _, c := range candidates {
+		m := trackPrefix.FindStringSubmatch(c)
+		if m == nil {
+			continue
+		}
+		for _, g := range m[1:] {
+			if g == "" {
+				continue
+			}
+			if n, err := strconv.Atoi(g); err == nil && n > 0 {
+				return n, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// SortSongsByTrackNumber returns a copy of the album with its songs in
+// track order as inferred by Song.TrackNumber. Songs without a track number
+// follow the numbered ones sorted by name, so an album with no numbering
+// at all comes back sorted by name.
+func (a AlbumWithSongs) SortSongsByTrackNumber() AlbumWithSongs {
+	type track struct {
+		n    int
+		song Song
+	}
+	var numbered []track
+	var rest Songs
+	for _, s := range a.Songs {
+		if n, ok := s.TrackNumber(); ok {
+			numbered = append(numbered, track{n, s})
+		} else {
+			rest = append(rest, s)
+		}
+	}
+	sort.SliceStable(numbered, func(i, j int) bool { return numbered[i].n < numbered[j].n })
+	SortSongs(rest, SortKeyName, Ascending)
+
+	out := a
+	out.Songs = make(Songs, 0, len(a.Songs))
+	for _, t := range numbered {
+		out.Songs = append(out.Songs, t.song)
+	}
+	out.Songs = append(out.Songs, rest...)
+	return out
+}
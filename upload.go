@@ -0,0 +1,118 @@
+package juicewrld
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"sort"
+)
+
+type UploadResult struct {
+	StatusCode int                    `json:"status_code"`
+	ID         string                 `json:"id"`
+	Path       string                 `json:"path"`
+	Message    string                 `json:"message"`
+	Raw        map[string]interface{} `json:"raw"`
+}
+
+type ProgressFunc func(sent, total int64)
+
+type uploadProgressKey struct{}
+
+func WithUploadProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, uploadProgressKey{}, fn)
+}
+
+func uploadProgressFrom(ctx context.Context) ProgressFunc {
+	fn, _ := ctx.Value(uploadProgressKey{}).(ProgressFunc)
+	return fn
+}
+
+type progressReader struct {
+	r        io.Reader
+	sent     int64
+	total    int64
+	progress ProgressFunc
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 {
+		p.sent += int64(n)
+		p.progress(p.sent, p.total)
+	}
+	return n, err
+}
+
+// UploadFile streams a multipart/form-data POST to endpointPath: fields
+// first, in key order, then r as fileField named fileName. size is r's
+// length, or -1 if unknown. The body is never buffered unless a
+// RequestSigner must hash it, counts against WithBandwidthLimit like
+// downloads, and stops when ctx is cancelled. Error statuses map to the
+// same errors as other calls.
+func (c *Client) UploadFile(ctx context.Context, endpointPath string, fields map[string]string, fileField, fileName string, r io.Reader, size int64) (UploadResult, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := mw.WriteField(k, fields[k]); err != nil {
+			return UploadResult{}, err
+		}
+	}
+	if _, err := mw.CreateFormFile(fileField, fileName); err != nil {
+		return UploadResult{}, err
+	}
+	prefix := append([]byte(nil), buf.Bytes()...)
+	buf.Reset()
+	if err := mw.Close(); err != nil {
+		return UploadResult{}, err
+	}
+	suffix := append([]byte(nil), buf.Bytes()...)
+
+	var file io.Reader = c.throttle(ctx, io.NopCloser(r))
+	if fn := uploadProgressFrom(ctx); fn != nil {
+		file = &progressReader{r: file, total: size, progress: fn}
+	}
+	body := io.MultiReader(bytes.NewReader(prefix), file, bytes.NewReader(suffix))
+
+	if _, err := c.baseURL(); err != nil {
+		return UploadResult{}, err
+	}
+	req, err := c.newRequest(ctx, http.MethodPost, c.endpointURL(endpointPath, nil), body, "application/json")
+	if err != nil {
+		return UploadResult{}, err
+	}
+	// A signed upload has already been buffered and knows its length.
+	if size >= 0 && req.GetBody == nil {
+		req.ContentLength = int64(len(prefix)) + size + int64(len(suffix))
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+
+	resp, err := c.sendRetrying(req)
+	if err != nil {
+		return UploadResult{}, err
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp); err != nil {
+		return UploadResult{}, err
+	}
+
+	res := UploadResult{StatusCode: resp.StatusCode}
+	if err := json.NewDecoder(resp.Body).Decode(&res.Raw); err != nil && err != io.EOF {
+		return res, err
+	}
+	if v, ok := res.Raw["id"]; ok && v != nil {
+		res.ID = fmt.Sprint(v)
+	}
+	res.Path, _ = res.Raw["path"].(string)
+	res.Message, _ = res.Raw["message"].(string)
+	return res, nil
+}
@@ -0,0 +1,145 @@
+package juicewrld
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newUploadAPI counts the body bytes the upload endpoint receives, even
+// when the client aborts mid-upload, and reports the file part's size.
+func newUploadAPI(t *testing.T, received *atomic.Int64) *fakeAPI {
+	t.Helper()
+	return newFakeAPI(t, map[string]http.HandlerFunc{
+		"/juicewrld/uploads/": func(w http.ResponseWriter, r *http.Request) {
+			body := io.TeeReader(r.Body, countingWriter{received})
+			mr, err := (&http.Request{Header: r.Header, Body: io.NopCloser(body)}).MultipartReader()
+			if err != nil {
+				statusHandler(http.StatusBadRequest)(w, r)
+				return
+			}
+			var fileSize int64
+			for {
+				part, err := mr.NextPart()
+				if err != nil {
+					break
+				}
+				n, _ := io.Copy(io.Discard, part)
+				if part.FormName() == "file" {
+					fileSize = n
+				}
+			}
+			writeJSON(w, http.StatusCreated, map[string]interface{}{"id": 5, "path": "uploads/song.mp3", "size": fileSize})
+		},
+	})
+}
+
+type countingWriter struct{ n *atomic.Int64 }
+
+func (w countingWriter) Write(p []byte) (int, error) {
+	w.n.Add(int64(len(p)))
+	return len(p), nil
+}
+
+func TestUploadFileStreamsWithProgress(t *testing.T) {
+	var received atomic.Int64
+	api := newUploadAPI(t, &received)
+	data := bytes.Repeat([]byte("x"), 200<<10)
+
+	var mu sync.Mutex
+	var last, total int64
+	ctx := WithUploadProgress(context.Background(), func(sent, size int64) {
+		mu.Lock()
+		last, total = sent, size
+		mu.Unlock()
+	})
+	res, err := api.client().UploadFile(ctx, "/juicewrld/uploads/", map[string]string{"title": "Song"}, "file", "song.mp3", bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusCreated || res.ID != "5" || res.Path != "uploads/song.mp3" || res.Raw["size"] != float64(len(data)) {
+		t.Errorf("UploadFile = %+v", res)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if last != int64(len(data)) || total != int64(len(data)) {
+		t.Errorf("last progress = %d of %d, want %d of %d", last, total, len(data), len(data))
+	}
+	if n := received.Load(); n <= int64(len(data)) {
+		t.Errorf("server received %d bytes, want the file and the multipart framing", n)
+	}
+}
+
+// stallingReader yields data, then blocks until ctx ends.
+type stallingReader struct {
+	data []byte
+	ctx  context.Context
+	sent chan struct{}
+}
+
+func (r *stallingReader) Read(p []byte) (int, error) {
+	if len(r.data) > 0 {
+		n := copy(p, r.data)
+		r.data = r.data[n:]
+		return n, nil
+	}
+	close(r.sent)
+	<-r.ctx.Done()
+	return 0, r.ctx.Err()
+}
+
+func TestUploadFileCancelledMidUpload(t *testing.T) {
+	var received atomic.Int64
+	api := newUploadAPI(t, &received)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	const size = 1 << 20
+	r := &stallingReader{data: bytes.Repeat([]byte("x"), 64<<10), ctx: ctx, sent: make(chan struct{})}
+	go func() {
+		<-r.sent
+		cancel()
+	}()
+
+	_, err := api.client().UploadFile(ctx, "/juicewrld/uploads/", nil, "file", "song.mp3", r, size)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+	if n := received.Load(); n >= size {
+		t.Errorf("server received %d bytes of an aborted %d-byte upload", n, size)
+	}
+}
+
+// waitRecordingClock returns from After at once and adds up the waits.
+type waitRecordingClock struct {
+	realClock
+	waited atomic.Int64
+}
+
+func (c *waitRecordingClock) After(d time.Duration) <-chan time.Time {
+	c.waited.Add(int64(d))
+	ch := make(chan time.Time, 1)
+	ch <- time.Now()
+	return ch
+}
+
+func TestUploadFileUsesBandwidthLimit(t *testing.T) {
+	var received atomic.Int64
+	api := newUploadAPI(t, &received)
+	clock := &waitRecordingClock{}
+	c := api.client(WithBandwidthLimit(64<<10), WithClock(clock))
+	data := bytes.Repeat([]byte("x"), 256<<10)
+
+	if _, err := c.UploadFile(context.Background(), "/juicewrld/uploads/", nil, "file", "song.mp3", bytes.NewReader(data), int64(len(data))); err != nil {
+		t.Fatal(err)
+	}
+	// 256 KiB at 64 KiB/s with a 64 KiB burst is about three seconds.
+	if w := time.Duration(clock.waited.Load()); w < 2*time.Second {
+		t.Errorf("upload throttled for %v, want about 3s", w)
+	}
+}
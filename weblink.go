@@ -0,0 +1,61 @@
+package juicewrld
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// WithWebBaseURL sets the frontend origin used by SongWebURL, for
+// deployments where it can't be derived from BaseURL.
+func WithWebBaseURL(webBaseURL string) Option {
+	return func(c *Client) {
+		c.webBaseURL = strings.TrimRight(webBaseURL, "/")
+	}
+}
+
+// webBase returns the configured frontend origin or, by default, BaseURL's
+// scheme and host with a leading "api." removed.
+func (c *Client) webBase() string {
+	if c.webBaseURL != "" {
+		return c.webBaseURL
+	}
+	base, err := c.baseURL()
+	if err != nil || base.Host == "" {
+		return strings.TrimRight(c.BaseURL, "/")
+	}
+	u := url.URL{Scheme: base.Scheme, Host: strings.TrimPrefix(base.Host, "api.")}
+	return u.String()
+}
+
+// SongWebURL returns the shareable frontend link for a song ID.
+func (c *Client) SongWebURL(songID int) string {
+	return c.songLink(strconv.Itoa(songID))
+}
+
+// SongWebURLFor links to s by its public ID when the API sent one, falling
+// back to the numeric ID.
+func (c *Client) SongWebURLFor(s Song) string {
+	if id, ok := s.PublicIDString(); ok {
+		return c.songLink(id)
+	}
+	return c.SongWebURL(s.ID)
+}
+
+func (c *Client) songLink(id string) string {
+	return c.webBase() + "/songs/" + url.PathEscape(id)
+}
+
+// PublicIDString returns the song's public ID as a string. The API sends it
+// as a string, a number or null; ok is false for null or empty values.
+func (s Song) PublicIDString() (id string, ok bool) {
+	switch v := s.PublicID.(type) {
+	case string:
+		id = strings.TrimSpace(v)
+	case float64:
+		id = strconv.FormatFloat(v, 'f', -1, 64)
+	case int:
+		id = strconv.Itoa(v)
+	}
+	return id, id != ""
+}
@@ -0,0 +1,376 @@
+package juicewrld
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultZipPollInterval = 2 * time.Second
+
+// maxHintedZipPollInterval caps how long an ETA alone can stretch the
+// interval between polls.
+const maxHintedZipPollInterval = 30 * time.Second
+
+type ZipJobStatus struct {
+	JobID       string  `json:"job_id"`
+	Status      string  `json:"status"`
+	Progress    float64 `json:"progress"`
+	DownloadURL string  `json:"download_url"`
+	Error       string  `json:"error"`
+
+	// RetryAfter is the poll interval suggested by the server through
+	// retry_after or poll_interval, in seconds on the wire.
+	RetryAfter time.Duration `json:"-"`
+	// ETA is the estimated time remaining when the status was produced,
+	// from a numeric eta field.
+	ETA time.Duration `json:"-"`
+	// EstimatedCompletion is set when the server sends eta or
+	// estimated_completion as a timestamp instead.
+	EstimatedCompletion time.Time `json:"-"`
+}
+
+func (s *ZipJobStatus) UnmarshalJSON(data []byte) error {
+	type alias ZipJobStatus
+	raw := struct {
+		*alias
+		RetryAfter          json.RawMessage `json:"retry_after"`
+		PollInterval        json.RawMessage `json:"poll_interval"`
+		ETA                 json.RawMessage `json:"eta"`
+		EstimatedCompletion json.RawMessage `json:"estimated_completion"`
+	}{alias: (*alias)(s)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if d, ok := hintSeconds(raw.RetryAfter); ok {
+		s.RetryAfter = d
+	} else if d, ok := hintSeconds(raw.PollInterval); ok {
+		s.RetryAfter = d
+	}
+	if d, ok := hintSeconds(raw.ETA); ok {
+		s.ETA = d
+	} else if t, ok := hintTime(raw.ETA); ok {
+		s.EstimatedCompletion = t
+	}
+	if t, ok := hintTime(raw.EstimatedCompletion); ok && s.EstimatedCompletion.IsZero() {
+		s.EstimatedCompletion = t
+	}
+	return nil
+}
+
+// hintSeconds accepts a number of seconds sent either as a JSON number or
+// as a numeric string.
+func hintSeconds(data json.RawMessage) (time.Duration, bool) {
+	if len(data) == 0 || string(data) == "null" {
+		return 0, false
+	}
+	text := strings.Trim(string(data), `"`)
+	secs, err := strconv.ParseFloat(text, 64)
+	if err != nil || secs <= 0 {
+		return 0, false
+	}
+	return time.Duration(secs * float64(time.Second)), true
+}
+
+func hintTime(data json.RawMessage) (time.Time, bool) {
+	var ft FlexibleTime
+	if len(data) == 0 || json.Unmarshal(data, &ft) != nil || ft.IsZero() {
+		return time.Time{}, false
+	}
+	return ft.Time, true
+}
+
+// NextPollDelay returns how long to wait before polling the job again. A
+// server-suggested interval wins; otherwise half the remaining ETA is used,
+// kept between fallback and 30 seconds. Without hints it returns fallback.
+func (s ZipJobStatus) NextPollDelay(now time.Time, fallback time.Duration) time.Duration {
+	if s.RetryAfter > 0 {
+		return s.RetryAfter
+	}
+	remaining := s.ETA
+	if remaining <= 0 && !s.EstimatedCompletion.IsZero() {
+		remaining = s.EstimatedCompletion.Sub(now)
+	}
+	if remaining <= 0 {
+		return fallback
+	}
+	return min(max(remaining/2, fallback), max(maxHintedZipPollInterval, fallback))
+}
+
+func (s ZipJobStatus) IsComplete() bool {
+	switch strings.ToLower(s.Status) {
+	case "completed", "complete", "done", "finished", "success":
+		return true
+	}
+	return false
+}
+
+func (s ZipJobStatus) IsFailed() bool {
+	switch strings.ToLower(s.Status) {
+	case "failed", "error":
+		return true
+	}
+	return false
+}
+
+func (s ZipJobStatus) IsCancelled() bool {
+	switch strings.ToLower(s.Status) {
+	case "cancelled", "canceled":
+		return true
+	}
+	return false
+}
+
+type CancelResult struct {
+	Cancelled     bool   `json:"cancelled"`
+	PreviousState string `json:"previous_state"`
+	Message       string `json:"message"`
+}
+
+func (r *CancelResult) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Cancelled     *bool  `json:"cancelled"`
+		Canceled      *bool  `json:"canceled"`
+		Success       *bool  `json:"success"`
+		PreviousState string `json:"previous_state"`
+		Status        string `json:"status"`
+		Message       string `json:"message"`
+		Error         string `json:"error"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	r.PreviousState = raw.PreviousState
+	if r.PreviousState == "" {
+		r.PreviousState = raw.Status
+	}
+	r.Message = raw.Message
+	if r.Message == "" {
+		r.Message = raw.Error
+	}
+	switch {
+	case raw.Cancelled != nil:
+		r.Cancelled = *raw.Cancelled
+	case raw.Canceled != nil:
+		r.Cancelled = *raw.Canceled
+	case raw.Success != nil:
+		r.Cancelled = *raw.Success
+	default:
+		prev := ZipJobStatus{Status: r.PreviousState}
+		r.Cancelled = raw.Error == "" && !prev.IsComplete() && !prev.IsFailed()
+	}
+	return nil
+}
+
+func (c *Client) GetZipJob(ctx context.Context, jobID string) (ZipJobStatus, error) {
+	var out ZipJobStatus
+	err := c.get(ctx, fmt.Sprintf("/juicewrld/zip-job-status/%s/", url.PathEscape(jobID)), nil, &out)
+	if out.JobID == "" {
+		out.JobID = jobID
+	}
+	if err == nil && (out.IsFailed() || out.IsCancelled()) {
+		c.zipDedup.forget(jobID)
+	}
+	return out, err
+}
+
+func (c *Client) CancelZipJobResult(ctx context.Context, jobID string) (CancelResult, error) {
+	var out CancelResult
+	err := c.post(ctx, fmt.Sprintf("/juicewrld/cancel-zip-job/%s/", url.PathEscape(jobID)), nil, &out)
+	if err != nil {
+		return CancelResult{}, err
+	}
+	if out.Cancelled {
+		c.zipWatches.signal(jobID)
+		c.zipDedup.forget(jobID)
+	}
+	return out, nil
+}
+
+func (c *Client) WaitForZipJob(ctx context.Context, jobID string, interval, maxWait time.Duration) (ZipJobStatus, error) {
+	if interval <= 0 {
+		interval = defaultZipPollInterval
+	}
+	cancelled := c.zipWatches.watch(jobID)
+	defer c.zipWatches.unwatch(jobID)
+
+	var deadline <-chan time.Time
+	if maxWait > 0 {
+		deadline = c.after(maxWait)
+	}
+
+	last := ZipJobStatus{JobID: jobID}
+	poll := c.after(0)
+	for {
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return last, &ZipJobTimeoutError{JobID: jobID, LastStatus: last, Err: ctx.Err()}
+			}
+			return last, ctx.Err()
+		case <-deadline:
+			return last, &ZipJobTimeoutError{JobID: jobID, LastStatus: last}
+		case <-cancelled:
+			last.Status = "cancelled"
+			return last, &JobCancelledError{JobID: jobID}
+		case <-poll:
+		}
+
+		status, err := c.GetZipJob(ctx, jobID)
+		if err != nil {
+			if ctx.Err() != nil {
+				continue
+			}
+			return last, err
+		}
+		last = status
+		switch {
+		case status.IsComplete():
+			return status, nil
+		case status.IsCancelled():
+			return status, &JobCancelledError{JobID: jobID}
+		case status.IsFailed():
+			return status, &JobFailedError{JobID: jobID, Status: status}
+		}
+		poll = c.after(status.NextPollDelay(c.now(), interval))
+	}
+}
+
+type zipWatchSet struct {
+	mu      sync.Mutex
+	watches map[string]*zipWatch
+}
+
+type zipWatch struct {
+	done    chan struct{}
+	closed  bool
+	waiters int
+}
+
+func (s *zipWatchSet) watch(jobID string) <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.watches == nil {
+		s.watches = make(map[string]*zipWatch)
+	}
+	w, ok := s.watches[jobID]
+	if !ok {
+		w = &zipWatch{done: make(chan struct{})}
+		s.watches[jobID] = w
+	}
+	w.waiters++
+	return w.done
+}
+
+func (s *zipWatchSet) unwatch(jobID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w, ok := s.watches[jobID]
+	if !ok {
+		return
+	}
+	w.waiters--
+	if w.waiters <= 0 {
+		delete(s.watches, jobID)
+	}
+}
+
+func (s *zipWatchSet) signal(jobID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if w, ok := s.watches[jobID]; ok && !w.closed {
+		w.closed = true
+		close(w.done)
+	}
+}
+
+// WithZipJobDedup makes StartZipJob return the existing job ID when the same
+// set of paths, in any order, was submitted within window. Jobs that are
+// seen failing or get cancelled through the client are not reused.
+func WithZipJobDedup(window time.Duration) Option {
+	return func(c *Client) {
+		c.zipDedup.window = window
+	}
+}
+
+type zipDedup struct {
+	window time.Duration
+
+	mu   sync.Mutex
+	jobs map[string]*zipDedupEntry
+}
+
+type zipDedupEntry struct {
+	ready     chan struct{}
+	jobID     string
+	err       error
+	startedAt time.Time
+}
+
+func zipDedupKey(paths []string) string {
+	sorted := slices.Clone(paths)
+	slices.Sort(sorted)
+	sorted = slices.Compact(sorted)
+	h := sha256.Sum256([]byte(strings.Join(sorted, "\x00")))
+	return hex.EncodeToString(h[:])
+}
+
+// start runs fn unless an identical request started within the window, in
+// which case it waits for that request and returns its job ID.
+func (d *zipDedup) start(ctx context.Context, now time.Time, paths []string, fn func(context.Context, []string) (string, error)) (string, error) {
+	key := zipDedupKey(paths)
+	d.mu.Lock()
+	if d.jobs == nil {
+		d.jobs = make(map[string]*zipDedupEntry)
+	}
+	for k, e := range d.jobs {
+		if e.jobID != "" && now.Sub(e.startedAt) >= d.window {
+			delete(d.jobs, k)
+		}
+	}
+	if e, ok := d.jobs[key]; ok {
+		d.mu.Unlock()
+		select {
+		case <-e.ready:
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+		if e.err == nil {
+			return e.jobID, nil
+		}
+		return fn(ctx, paths)
+	}
+	e := &zipDedupEntry{ready: make(chan struct{}), startedAt: now}
+	d.jobs[key] = e
+	d.mu.Unlock()
+
+	jobID, err := fn(ctx, paths)
+
+	d.mu.Lock()
+	e.jobID, e.err = jobID, err
+	if err != nil || jobID == "" {
+		delete(d.jobs, key)
+	}
+	d.mu.Unlock()
+	close(e.ready)
+	return jobID, err
+}
+
+func (d *zipDedup) forget(jobID string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for k, e := range d.jobs {
+		if e.jobID == jobID {
+			delete(d.jobs, k)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package juicewrld
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newZipJobsAPI serves job "running", still processing, and job "done",
+// already complete. Any other job ID is unknown.
+func newZipJobsAPI(t *testing.T) *fakeAPI {
+	t.Helper()
+	return newFakeAPI(t, map[string]http.HandlerFunc{
+		"/juicewrld/cancel-zip-job/running/": jsonHandler(map[string]interface{}{"cancelled": true, "previous_state": "processing"}),
+		"/juicewrld/cancel-zip-job/done/":    jsonHandler(map[string]interface{}{"error": "job already completed", "status": "completed"}),
+		"/juicewrld/zip-job-status/running/": jsonHandler(map[string]interface{}{"job_id": "running", "status": "processing"}),
+	})
+}
+
+// deadlineClock fires After at once for a zero wait or the deadline and never
+// for anything else, so only the deadline can end a wait.
+type deadlineClock struct {
+	realClock
+	deadline time.Duration
+}
+
+func (c deadlineClock) After(d time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	if d == 0 || d == c.deadline {
+		ch <- time.Now()
+	}
+	return ch
+}
+
+func TestCancelZipJob(t *testing.T) {
+	api := newZipJobsAPI(t)
+	ctx := context.Background()
+	c := api.client()
+
+	res, err := c.CancelZipJobResult(ctx, "running")
+	if err != nil || res != (CancelResult{Cancelled: true, PreviousState: "processing"}) {
+		t.Errorf("cancel running job = %+v, %v", res, err)
+	}
+
+	res, err = c.CancelZipJobResult(ctx, "done")
+	if err != nil || res.Cancelled || res.PreviousState != "completed" || res.Message != "job already completed" {
+		t.Errorf("cancel finished job = %+v, %v; want not cancelled", res, err)
+	}
+	if ok, err := c.CancelZipJob(ctx, "done"); ok || err != nil {
+		t.Errorf("CancelZipJob(done) = %v, %v; want false", ok, err)
+	}
+
+	_, err = c.CancelZipJobResult(ctx, "missing")
+	var notFound *NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Errorf("cancel unknown job: err = %v, want a NotFoundError", err)
+	}
+}
+
+func TestWaitForZipJobStopsOnConcurrentCancel(t *testing.T) {
+	api := newZipJobsAPI(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	c := api.client()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := c.WaitForZipJob(ctx, "running", time.Hour, 0)
+		done <- err
+	}()
+	// Cancel once the waiter has polled, so it is parked on its timer.
+	for api.hitCount("/juicewrld/zip-job-status/running/") == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	if ok, err := c.CancelZipJob(ctx, "running"); !ok || err != nil {
+		t.Fatalf("CancelZipJob = %v, %v", ok, err)
+	}
+
+	select {
+	case err := <-done:
+		var cancelled *JobCancelledError
+		if !errors.As(err, &cancelled) || !strings.Contains(err.Error(), "running") {
+			t.Errorf("WaitForZipJob err = %v, want a JobCancelledError for the job", err)
+		}
+	case <-ctx.Done():
+		t.Fatal("WaitForZipJob kept polling after the job was cancelled")
+	}
+}
+
+func TestWaitForZipJobDeadlineUsesClientClock(t *testing.T) {
+	api := newZipJobsAPI(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	c := api.client(WithClock(deadlineClock{deadline: 2 * time.Hour}))
+
+	_, err := c.WaitForZipJob(ctx, "running", time.Hour, 2*time.Hour)
+	var timeout *ZipJobTimeoutError
+	if !errors.As(err, &timeout) || timeout.Err != nil {
+		t.Errorf("WaitForZipJob err = %v, want the maxWait ZipJobTimeoutError from the injected clock", err)
+	}
+}